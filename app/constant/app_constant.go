@@ -9,12 +9,14 @@ const (
 	DataNotFound
 	Conflict
 	UnknownError
+	TooManyRequests
+	Timeout
 )
 
 func (r ResponseStatus) GetResponseStatus() string {
-	return [...]string{"SUCCESS", "INVALID_REQUEST", "UNAUTHORIZED", "DATA_NOT_FOUND", "CONFLICT", "UNKNOWN_ERROR"}[r-1]
+	return [...]string{"SUCCESS", "INVALID_REQUEST", "UNAUTHORIZED", "DATA_NOT_FOUND", "CONFLICT", "UNKNOWN_ERROR", "TOO_MANY_REQUESTS", "TIMEOUT"}[r-1]
 }
 
 func (r ResponseStatus) GetResponseMessage() string {
-	return [...]string{"Success", "Invalid Request", "Unauthorized", "Data Not Found", "Conflict", "Unknown Error"}[r-1]
+	return [...]string{"Success", "Invalid Request", "Unauthorized", "Data Not Found", "Conflict", "Unknown Error", "Too Many Requests", "Request Timeout"}[r-1]
 }
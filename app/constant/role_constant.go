@@ -0,0 +1,19 @@
+package constant
+
+const (
+	RoleAdmin     = "ADMIN"
+	RoleUser      = "USER"
+	RoleOrganizer = "ORGANIZER"
+)
+
+const (
+	PermissionCreateEvent   = "events:create"
+	PermissionRegisterEvent = "events:register"
+	PermissionManageEvent   = "events:manage"
+	PermissionReadUsers     = "users:read"
+	PermissionReadRoles     = "roles:read"
+	PermissionAssignRole    = "roles:assign"
+	PermissionUnlockUsers   = "users:unlock"
+	PermissionManageRoles   = "roles:manage"
+	PermissionReadAttendees = "attendees:read"
+)
@@ -1,28 +1,32 @@
 package controller
 
 import (
-	"errors"
 	"event-booking-api/app/constant"
 	"event-booking-api/app/domain/dao"
-	_ "event-booking-api/app/domain/dto"
+	"event-booking-api/app/domain/dto"
 	"event-booking-api/app/pkg"
 	"event-booking-api/app/service"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	log "github.com/sirupsen/logrus"
 )
 
+const searchSnippetLength = 160
+
 type EventController interface {
 	AddEvent(c *gin.Context)
 	GetAllEvent(c *gin.Context)
+	SearchEvent(c *gin.Context)
 	GetEventById(c *gin.Context)
 	UpdateEventById(c *gin.Context)
 	DeleteEventById(c *gin.Context)
 	RegisterUserForEvent(c *gin.Context)
 	UnregisterUserForEvent(c *gin.Context)
+	GetRegisterById(c *gin.Context)
+	GetWaitlistById(c *gin.Context)
 	GetAttendeesEmailById(c *gin.Context)
 }
 
@@ -46,25 +50,25 @@ type EventControllerImpl struct {
 //	@Router			/events [post]
 //	@Security		BearerAuth
 func (e EventControllerImpl) AddEvent(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	var request dao.Event
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Info("Error parsing request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
 	}
 
-	validate := validator.New()
-	if err := validate.StructExcept(request, "User"); err != nil {
+	if err := pkg.ValidateStruct(request, "User"); err != nil {
 		log.Info("Error validating request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	request.UserID = c.GetInt("userId")
 
-	event, err := e.eventSvc.AddEvent(request)
+	event, err := e.eventSvc.AddEvent(c.Request.Context(), request)
 	if err != nil {
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.EventResponse{
@@ -73,6 +77,7 @@ func (e EventControllerImpl) AddEvent(c *gin.Context) {
 		Description: event.Description,
 		Location:    event.Location,
 		EventTime:   event.EventTime,
+		Capacity:    event.Capacity,
 		UserID:      event.UserID,
 	}
 
@@ -82,18 +87,51 @@ func (e EventControllerImpl) AddEvent(c *gin.Context) {
 // GetAllEvent godoc
 //
 //	@Summary		Get all events
-//	@Description	Retrieve a list of events
+//	@Description	Retrieve a page of events, optionally filtered and sorted. Use either page or cursor based pagination.
 //	@Tags			events
 //	@Produce		json
-//	@Success		200	{object}	dto.ApiResponse[[]dao.EventResponse]	"Success"
-//	@Failure		500	{object}	dto.ApiResponse[any]					"Internal server error"
+//	@Param			page		query		int										false	"Page number, starting at 1"
+//	@Param			limit		query		int										false	"Page size, at most 100"
+//	@Param			cursor		query		string									false	"Cursor returned as next_cursor by the previous page"
+//	@Param			location	query		string									false	"Location contains"
+//	@Param			user_id		query		int										false	"Owner user ID"
+//	@Param			from		query		string									false	"Earliest event time (RFC3339)"
+//	@Param			to			query		string									false	"Latest event time (RFC3339)"
+//	@Param			upcoming	query		bool									false	"Only events that have not started yet"
+//	@Param			sort		query		string									false	"Sort field"	Enums(event_time, name)
+//	@Param			order		query		string									false	"Sort order"	Enums(asc, desc)
+//	@Success		200			{object}	dto.ApiResponse[[]dao.EventResponse]	"Success"
+//	@Failure		400			{object}	dto.ApiResponse[any]					"Bad request"
+//	@Failure		500			{object}	dto.ApiResponse[any]					"Internal server error"
 //	@Router			/events [get]
 func (e EventControllerImpl) GetAllEvent(c *gin.Context) {
-	defer pkg.PanicHandler(c)
+	var query dto.EventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Info("Error parsing request query: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request query", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(query); err != nil {
+		log.Info("Error validating request query: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
 
-	events, err := e.eventSvc.GetAllEvent()
+	events, pagination, err := e.eventSvc.GetAllEvent(c.Request.Context(), query)
 	if err != nil {
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	if pagination.NextCursor != "" {
+		values := c.Request.URL.Query()
+		if query.Cursor != "" {
+			values.Set("cursor", pagination.NextCursor)
+		} else {
+			values.Set("page", strconv.Itoa(pagination.Page+1))
+		}
+		pagination.Next = c.Request.URL.Path + "?" + values.Encode()
 	}
 
 	response := make([]dao.EventResponse, len(events))
@@ -104,11 +142,85 @@ func (e EventControllerImpl) GetAllEvent(c *gin.Context) {
 			Description: event.Description,
 			Location:    event.Location,
 			EventTime:   event.EventTime,
+			Capacity:    event.Capacity,
 			UserID:      event.UserID,
 		}
 	}
 
-	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+	c.JSON(http.StatusOK, pkg.BuildPageResponse(constant.Success, response, pagination))
+}
+
+// SearchEvent godoc
+//
+//	@Summary		Search events
+//	@Description	Full-text search across event name, description and location, ordered by relevance. Matched terms are highlighted with <mark> tags in HTML-escaped snippets.
+//	@Tags			events
+//	@Produce		json
+//	@Param			q		query		string										true	"Search query"
+//	@Param			page	query		int											false	"Page number, starting at 1"
+//	@Param			limit	query		int											false	"Page size, at most 100"
+//	@Success		200		{object}	dto.ApiResponse[[]dao.EventSearchResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]						"Bad request"
+//	@Failure		500		{object}	dto.ApiResponse[any]						"Internal server error"
+//	@Router			/events/search [get]
+func (e EventControllerImpl) SearchEvent(c *gin.Context) {
+	var query dto.EventSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Info("Error parsing request query: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request query", err))
+		return
+	}
+	// A blank query has no terms to search for, so it is rejected like a missing one.
+	query.Q = strings.TrimSpace(query.Q)
+
+	if err := pkg.ValidateStruct(query); err != nil {
+		log.Info("Error validating request query: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	results, pagination, err := e.eventSvc.SearchEvent(c.Request.Context(), query)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	if int64(pagination.Page*pagination.Limit) < pagination.Total {
+		values := c.Request.URL.Query()
+		values.Set("page", strconv.Itoa(pagination.Page+1))
+		pagination.Next = c.Request.URL.Path + "?" + values.Encode()
+	}
+
+	terms := pkg.SearchTerms(query.Q)
+	response := make([]dao.EventSearchResponse, len(results))
+	for i, result := range results {
+		highlight := make(map[string]string)
+		if snippet := pkg.Highlight(result.Name, terms, searchSnippetLength); snippet != "" {
+			highlight["name"] = snippet
+		}
+		if snippet := pkg.Highlight(result.Description, terms, searchSnippetLength); snippet != "" {
+			highlight["description"] = snippet
+		}
+		if snippet := pkg.Highlight(result.Location, terms, searchSnippetLength); snippet != "" {
+			highlight["location"] = snippet
+		}
+
+		response[i] = dao.EventSearchResponse{
+			EventResponse: dao.EventResponse{
+				ID:          result.ID,
+				Name:        result.Name,
+				Description: result.Description,
+				Location:    result.Location,
+				EventTime:   result.EventTime,
+				Capacity:    result.Capacity,
+				UserID:      result.UserID,
+			},
+			Score:     result.Score,
+			Highlight: highlight,
+		}
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildPageResponse(constant.Success, response, pagination))
 }
 
 // GetEventById godoc
@@ -123,18 +235,12 @@ func (e EventControllerImpl) GetAllEvent(c *gin.Context) {
 //	@Failure		500	{object}	dto.ApiResponse[any]				"Internal server error"
 //	@Router			/events/{id} [get]
 func (e EventControllerImpl) GetEventById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 
-	event, err := e.eventSvc.GetEventById(eventId)
+	event, err := e.eventSvc.GetEventById(c.Request.Context(), eventId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.EventResponse{
@@ -143,6 +249,7 @@ func (e EventControllerImpl) GetEventById(c *gin.Context) {
 		Description: event.Description,
 		Location:    event.Location,
 		EventTime:   event.EventTime,
+		Capacity:    event.Capacity,
 		UserID:      event.UserID,
 	}
 
@@ -157,34 +264,37 @@ func (e EventControllerImpl) GetEventById(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id		path		int									true	"Event ID"
-//	@Param			event	body		dao.Event							true	"Updated event data"
+//	@Param			event	body		dao.EventUpdateRequest				true	"Updated event data"
 //	@Success		200		{object}	dto.ApiResponse[dao.EventResponse]	"Success"
 //	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
 //	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
 //	@Failure		404		{object}	dto.ApiResponse[any]				"Not found"
+//	@Failure		409		{object}	dto.ApiResponse[any]				"Capacity below confirmed registrations"
 //	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
 //	@Router			/events/{id} [put]
 //	@Security		BearerAuth
 func (e EventControllerImpl) UpdateEventById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 	userId := c.GetInt("userId")
+	roleId := c.GetInt("roleId")
 
-	var request dao.Event
+	var request dao.EventUpdateRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Info("Error parsing request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
 	}
 
-	event, err := e.eventSvc.UpdateEventById(request, eventId, userId)
-	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
+	if err := pkg.ValidatePartial(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
 
-		pkg.PanicException(constant.UnknownError)
+	event, err := e.eventSvc.UpdateEventById(c.Request.Context(), request, eventId, userId, roleId)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.EventResponse{
@@ -193,6 +303,7 @@ func (e EventControllerImpl) UpdateEventById(c *gin.Context) {
 		Description: event.Description,
 		Location:    event.Location,
 		EventTime:   event.EventTime,
+		Capacity:    event.Capacity,
 		UserID:      event.UserID,
 	}
 
@@ -213,19 +324,14 @@ func (e EventControllerImpl) UpdateEventById(c *gin.Context) {
 //	@Router			/events/{id} [delete]
 //	@Security		BearerAuth
 func (e EventControllerImpl) DeleteEventById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 	userId := c.GetInt("userId")
+	roleId := c.GetInt("roleId")
 
-	err := e.eventSvc.DeleteEventById(eventId, userId)
+	err := e.eventSvc.DeleteEventById(c.Request.Context(), eventId, userId, roleId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
@@ -234,62 +340,136 @@ func (e EventControllerImpl) DeleteEventById(c *gin.Context) {
 // RegisterUserForEvent godoc
 //
 //	@Summary		Register user for a specific event
-//	@Description	Register user for a specific event by its ID. Requires JWT authentication.
+//	@Description	Register user for a specific event by its ID. The registration is waitlisted once the event is full. Requires JWT authentication.
 //	@Tags			events
 //	@Produce		json
-//	@Param			id	path		int						true	"Event ID"
-//	@Success		201	{object}	dto.ApiResponse[any]	"Created"
-//	@Failure		401	{object}	dto.ApiResponse[any]	"Unauthorized"
-//	@Failure		404	{object}	dto.ApiResponse[any]	"Not found"
-//	@Failure		409	{object}	dto.ApiResponse[any]	"Conflict"
-//	@Failure		500	{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Param			id	path		int										true	"Event ID"
+//	@Success		201	{object}	dto.ApiResponse[dao.RegisterResponse]	"Created"
+//	@Failure		401	{object}	dto.ApiResponse[any]					"Unauthorized"
+//	@Failure		404	{object}	dto.ApiResponse[any]					"Not found"
+//	@Failure		409	{object}	dto.ApiResponse[any]					"Conflict"
+//	@Failure		500	{object}	dto.ApiResponse[any]					"Internal server error"
 //	@Router			/events/{id}/register [post]
 //	@Security		BearerAuth
 func (e EventControllerImpl) RegisterUserForEvent(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 	userId := c.GetInt("userId")
 
-	err := e.registerSvc.RegisterUserForEvent(eventId, userId)
+	register, err := e.registerSvc.RegisterUserForEvent(c.Request.Context(), eventId, userId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
+		pkg.AbortWithError(c, err)
+		return
+	}
 
-		pkg.PanicException(constant.UnknownError)
+	response := dao.RegisterResponse{
+		ID:       register.ID,
+		EventID:  register.EventID,
+		UserID:   register.UserID,
+		Status:   register.Status,
+		Position: register.Position,
 	}
 
-	c.JSON(http.StatusCreated, pkg.BuildResponse(constant.Success, pkg.Null()))
+	c.JSON(http.StatusCreated, pkg.BuildResponse(constant.Success, response))
 }
 
 // UnregisterUserForEvent godoc
 //
 //	@Summary		Unregister user for a specific event
-//	@Description	Unregister user for a specific event by its ID. Requires JWT authentication.
+//	@Description	Cancel user registration for a specific event by its ID. The earliest waitlisted user is promoted if a seat becomes available. Requires JWT authentication.
 //	@Tags			events
 //	@Produce		json
 //	@Param			id	path		int						true	"Event ID"
 //	@Success		200	{object}	dto.ApiResponse[any]	"Success"
 //	@Failure		401	{object}	dto.ApiResponse[any]	"Unauthorized"
+//	@Failure		404	{object}	dto.ApiResponse[any]	"Not found"
 //	@Failure		500	{object}	dto.ApiResponse[any]	"Internal server error"
 //	@Router			/events/{id}/register [delete]
 //	@Security		BearerAuth
 func (e EventControllerImpl) UnregisterUserForEvent(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 	userId := c.GetInt("userId")
 
-	err := e.registerSvc.UnregisterUserForEvent(eventId, userId)
+	err := e.registerSvc.UnregisterUserForEvent(c.Request.Context(), eventId, userId)
 	if err != nil {
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
 }
 
+// GetRegisterById godoc
+//
+//	@Summary		Get user registration for a specific event
+//	@Description	Retrieve the registration status and waitlist position of the current user for a specific event. Requires JWT authentication.
+//	@Tags			events
+//	@Produce		json
+//	@Param			id	path		int										true	"Event ID"
+//	@Success		200	{object}	dto.ApiResponse[dao.RegisterResponse]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]					"Unauthorized"
+//	@Failure		404	{object}	dto.ApiResponse[any]					"Not found"
+//	@Failure		500	{object}	dto.ApiResponse[any]					"Internal server error"
+//	@Router			/events/{id}/register [get]
+//	@Security		BearerAuth
+func (e EventControllerImpl) GetRegisterById(c *gin.Context) {
+	eventId, _ := strconv.Atoi(c.Param("eventId"))
+	userId := c.GetInt("userId")
+
+	register, err := e.registerSvc.GetRegisterById(c.Request.Context(), eventId, userId)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := dao.RegisterResponse{
+		ID:       register.ID,
+		EventID:  register.EventID,
+		UserID:   register.UserID,
+		Status:   register.Status,
+		Position: register.Position,
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
+// GetWaitlistById godoc
+//
+//	@Summary		Get event waitlist
+//	@Description	Retrieve the ordered waitlist of a specific event. Requires JWT authentication.
+//	@Tags			events
+//	@Produce		json
+//	@Param			id	path		int										true	"Event ID"
+//	@Success		200	{object}	dto.ApiResponse[[]dao.RegisterResponse]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]					"Unauthorized"
+//	@Failure		404	{object}	dto.ApiResponse[any]					"Not found"
+//	@Failure		500	{object}	dto.ApiResponse[any]					"Internal server error"
+//	@Router			/events/{id}/register/waitlist [get]
+//	@Security		BearerAuth
+func (e EventControllerImpl) GetWaitlistById(c *gin.Context) {
+	eventId, _ := strconv.Atoi(c.Param("eventId"))
+	userId := c.GetInt("userId")
+	roleId := c.GetInt("roleId")
+
+	registers, err := e.registerSvc.GetWaitlistById(c.Request.Context(), eventId, userId, roleId)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]dao.RegisterResponse, len(registers))
+	for i, register := range registers {
+		response[i] = dao.RegisterResponse{
+			ID:       register.ID,
+			EventID:  register.EventID,
+			UserID:   register.UserID,
+			Status:   register.Status,
+			Position: register.Position,
+		}
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
 // GetAttendeesEmailById godoc
 //
 //	@Summary		Get all event attendees email
@@ -304,19 +484,14 @@ func (e EventControllerImpl) UnregisterUserForEvent(c *gin.Context) {
 //	@Router			/events/{id}/attendees [get]
 //	@Security		BearerAuth
 func (e EventControllerImpl) GetAttendeesEmailById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	eventId, _ := strconv.Atoi(c.Param("eventId"))
 	userId := c.GetInt("userId")
+	roleId := c.GetInt("roleId")
 
-	emails, err := e.registerSvc.GetAttendeesEmailById(eventId, userId)
+	emails, err := e.registerSvc.GetAttendeesEmailById(c.Request.Context(), eventId, userId, roleId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, emails))
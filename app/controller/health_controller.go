@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController interface {
+	GetLiveness(c *gin.Context)
+	GetReadiness(c *gin.Context)
+}
+
+type HealthControllerImpl struct {
+	healthSvc service.HealthService
+}
+
+// GetLiveness reports that the process is alive, without checking its dependencies.
+func (h HealthControllerImpl) GetLiveness(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, h.healthSvc.CheckLiveness())
+}
+
+// GetReadiness reports whether the application can serve requests, with the status of each dependency.
+// It responds with 503 if any dependency is down. The health is returned without the dto.ApiResponse
+// envelope so that orchestrators and load balancers can consume it directly.
+func (h HealthControllerImpl) GetReadiness(c *gin.Context) {
+	health := h.healthSvc.CheckReadiness(c.Request.Context())
+
+	status := http.StatusOK
+	if health.Status != dto.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, health)
+}
+
+func HealthControllerInit(healthService service.HealthService) *HealthControllerImpl {
+	return &HealthControllerImpl{
+		healthSvc: healthService,
+	}
+}
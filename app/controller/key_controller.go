@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"event-booking-api/app/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KeyController interface {
+	GetJWKS(c *gin.Context)
+}
+
+type KeyControllerImpl struct {
+	tokenSvc service.TokenService
+}
+
+// GetJWKS serves the public signing keys as a standard JSON Web Key Set.
+// The key set is returned without the dto.ApiResponse envelope so that JWT libraries can consume it directly.
+func (k KeyControllerImpl) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, k.tokenSvc.GetJWKS())
+}
+
+func KeyControllerInit(tokenService service.TokenService) *KeyControllerImpl {
+	return &KeyControllerImpl{
+		tokenSvc: tokenService,
+	}
+}
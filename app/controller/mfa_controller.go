@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"event-booking-api/app/constant"
+	"event-booking-api/app/domain/dao"
+	_ "event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type MfaController interface {
+	EnrollMfa(c *gin.Context)
+	ActivateMfa(c *gin.Context)
+	DisableMfa(c *gin.Context)
+	RegenerateRecoveryCodes(c *gin.Context)
+}
+
+type MfaControllerImpl struct {
+	mfaSvc service.MfaService
+}
+
+// EnrollMfa godoc
+//
+//	@Summary		Start MFA enrollment
+//	@Description	Generate a TOTP secret and its otpauth provisioning URI, to be shown as a QR code. MFA is enabled once the secret is activated. Requires JWT authentication.
+//	@Tags			mfa
+//	@Produce		json
+//	@Success		200	{object}	dto.ApiResponse[dao.MfaEnrollmentResponse]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]						"Unauthorized"
+//	@Failure		409	{object}	dto.ApiResponse[any]						"Conflict"
+//	@Failure		500	{object}	dto.ApiResponse[any]						"Internal server error"
+//	@Router			/users/mfa/enroll [post]
+//	@Security		BearerAuth
+func (m MfaControllerImpl) EnrollMfa(c *gin.Context) {
+	enrollment, err := m.mfaSvc.EnrollMfa(c.Request.Context(), c.GetInt("userId"))
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, enrollment))
+}
+
+// ActivateMfa godoc
+//
+//	@Summary		Activate MFA
+//	@Description	Enable MFA with a code from the authenticator holding the enrolled secret. Returns single-use recovery codes, which are only shown once. Requires JWT authentication.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.MfaCodeRequest							true	"TOTP code"
+//	@Success		200		{object}	dto.ApiResponse[dao.RecoveryCodesResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]						"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]						"Unauthorized"
+//	@Failure		409		{object}	dto.ApiResponse[any]						"Conflict"
+//	@Failure		500		{object}	dto.ApiResponse[any]						"Internal server error"
+//	@Router			/users/mfa/activate [post]
+//	@Security		BearerAuth
+func (m MfaControllerImpl) ActivateMfa(c *gin.Context) {
+	request, ok := bindMfaCodeRequest(c)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := m.mfaSvc.ActivateMfa(c.Request.Context(), c.GetInt("userId"), request.Code)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, dao.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
+
+// DisableMfa godoc
+//
+//	@Summary		Disable MFA
+//	@Description	Turn MFA off with a TOTP or recovery code. Not allowed when the user's role requires MFA. Requires JWT authentication.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.MfaCodeRequest		true	"TOTP or recovery code"
+//	@Success		200		{object}	dto.ApiResponse[any]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]	"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]	"Unauthorized"
+//	@Failure		409		{object}	dto.ApiResponse[any]	"Conflict"
+//	@Failure		500		{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Router			/users/mfa/disable [post]
+//	@Security		BearerAuth
+func (m MfaControllerImpl) DisableMfa(c *gin.Context) {
+	request, ok := bindMfaCodeRequest(c)
+	if !ok {
+		return
+	}
+
+	err := m.mfaSvc.DisableMfa(c.Request.Context(), c.GetInt("userId"), request.Code)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Replace the user's recovery codes with new ones, after checking a TOTP or recovery code. Requires JWT authentication.
+//	@Tags			mfa
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.MfaCodeRequest							true	"TOTP or recovery code"
+//	@Success		200		{object}	dto.ApiResponse[dao.RecoveryCodesResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]						"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]						"Unauthorized"
+//	@Failure		500		{object}	dto.ApiResponse[any]						"Internal server error"
+//	@Router			/users/mfa/recovery-codes [post]
+//	@Security		BearerAuth
+func (m MfaControllerImpl) RegenerateRecoveryCodes(c *gin.Context) {
+	request, ok := bindMfaCodeRequest(c)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := m.mfaSvc.RegenerateRecoveryCodes(c.Request.Context(), c.GetInt("userId"), request.Code)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, dao.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
+
+// bindMfaCodeRequest parses and validates the MFA code of the request, aborting it if the code is missing.
+// It returns the dao.MfaCodeRequest and whether the request can proceed.
+func bindMfaCodeRequest(c *gin.Context) (dao.MfaCodeRequest, bool) {
+	var request dao.MfaCodeRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return dao.MfaCodeRequest{}, false
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return dao.MfaCodeRequest{}, false
+	}
+
+	return request, true
+}
+
+func MfaControllerInit(mfaService service.MfaService) *MfaControllerImpl {
+	return &MfaControllerImpl{
+		mfaSvc: mfaService,
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"event-booking-api/app/constant"
+	_ "event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type OidcController interface {
+	LoginOidc(c *gin.Context)
+	OidcCallback(c *gin.Context)
+}
+
+type OidcControllerImpl struct {
+	oidcSvc service.OidcService
+}
+
+// LoginOidc godoc
+//
+//	@Summary		Start an OpenID Connect login
+//	@Description	Redirect to the identity provider to sign in with an authorization code flow with PKCE. The provider redirects back to the callback endpoint within 10 minutes. Not found when OpenID Connect login is not configured.
+//	@Tags			users
+//	@Produce		json
+//	@Success		302	"Redirect to the identity provider"
+//	@Failure		404	{object}	dto.ApiResponse[any]	"Not configured"
+//	@Failure		500	{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Router			/users/oidc/login [get]
+func (o OidcControllerImpl) LoginOidc(c *gin.Context) {
+	authURL, err := o.oidcSvc.AuthorizationURL(c.Request.Context())
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OidcCallback godoc
+//
+//	@Summary		Complete an OpenID Connect login
+//	@Description	Exchange the authorization code returned by the identity provider and return a short-lived JWT access token and a refresh token. The provider account is linked to the user with the same verified email, or to a new user, and the user's role follows the configured group mapping. Users with MFA, or whose role requires it, get an MFA token to complete the login with instead.
+//	@Tags			users
+//	@Produce		json
+//	@Param			code	query		string								true	"Authorization code"
+//	@Param			state	query		string								true	"State of the login"
+//	@Success		200		{object}	dto.ApiResponse[dao.LoginResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		404		{object}	dto.ApiResponse[any]				"Not configured"
+//	@Failure		429		{object}	dto.ApiResponse[any]				"Too many requests"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/users/oidc/callback [get]
+func (o OidcControllerImpl) OidcCallback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		log.Info("Login rejected by identity provider: ", providerErr)
+		pkg.AbortWithError(c, pkg.NewUnauthorizedError("Login rejected by identity provider: "+providerErr, nil))
+		return
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		log.Info("Error parsing request data: missing code or state")
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Missing code or state", nil))
+		return
+	}
+
+	login, err := o.oidcSvc.Callback(c.Request.Context(), code, state, c.ClientIP())
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, login))
+}
+
+func OidcControllerInit(oidcService service.OidcService) *OidcControllerImpl {
+	return &OidcControllerImpl{
+		oidcSvc: oidcService,
+	}
+}
@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"event-booking-api/app/constant"
+	"event-booking-api/app/domain/dao"
+	_ "event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+type RoleController interface {
+	GetAllRole(c *gin.Context)
+	UpdateRoleMfaById(c *gin.Context)
+}
+
+type RoleControllerImpl struct {
+	roleSvc service.RoleService
+}
+
+// GetAllRole godoc
+//
+//	@Summary		Get all roles
+//	@Description	Retrieve a list of roles and their permissions. Admin only. Requires JWT authentication.
+//	@Tags			roles
+//	@Produce		json
+//	@Success		200	{object}	dto.ApiResponse[[]dao.RoleResponse]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		500	{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/roles [get]
+//	@Security		BearerAuth
+func (r RoleControllerImpl) GetAllRole(c *gin.Context) {
+	roles, err := r.roleSvc.GetAllRole(c.Request.Context())
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]dao.RoleResponse, len(roles))
+	for i, role := range roles {
+		permissions := make([]string, len(role.Permissions))
+		for j, permission := range role.Permissions {
+			permissions[j] = permission.Name
+		}
+
+		response[i] = dao.RoleResponse{
+			ID:          role.ID,
+			Role:        role.Role,
+			MfaRequired: role.MfaRequired,
+			Permissions: permissions,
+		}
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
+// UpdateRoleMfaById godoc
+//
+//	@Summary		Require MFA for a role
+//	@Description	Set whether users of a role must sign in with MFA. Users who have not enabled MFA are enrolled at their next login. Admin only. Requires JWT authentication.
+//	@Tags			roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int									true	"Role ID"
+//	@Param			mfa		body		dao.RoleMfaRequest					true	"MFA requirement"
+//	@Success		200		{object}	dto.ApiResponse[dao.RoleResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		404		{object}	dto.ApiResponse[any]				"Not found"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/roles/{id}/mfa [put]
+//	@Security		BearerAuth
+func (r RoleControllerImpl) UpdateRoleMfaById(c *gin.Context) {
+	roleId, _ := strconv.Atoi(c.Param("roleId"))
+
+	var request dao.RoleMfaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	role, err := r.roleSvc.UpdateRoleMfaById(c.Request.Context(), roleId, *request.Required)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := dao.RoleResponse{
+		ID:          role.ID,
+		Role:        role.Role,
+		MfaRequired: role.MfaRequired,
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
+func RoleControllerInit(roleService service.RoleService) *RoleControllerImpl {
+	return &RoleControllerImpl{
+		roleSvc: roleService,
+	}
+}
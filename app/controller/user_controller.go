@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"errors"
 	"event-booking-api/app/constant"
 	"event-booking-api/app/domain/dao"
 	_ "event-booking-api/app/domain/dto"
@@ -10,7 +9,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/go-playground/validator/v10"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/gin-gonic/gin"
@@ -22,7 +20,17 @@ type UserController interface {
 	GetUserById(c *gin.Context)
 	UpdateUserById(c *gin.Context)
 	DeleteUserById(c *gin.Context)
+	UpdateUserRoleById(c *gin.Context)
+	UnlockUserById(c *gin.Context)
+	GetLoginHistoryById(c *gin.Context)
 	LoginUser(c *gin.Context)
+	VerifyMfaLogin(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	LogoutUser(c *gin.Context)
+	RequestPasswordReset(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	VerifyEmail(c *gin.Context)
+	ResendVerification(c *gin.Context)
 }
 
 type UserControllerImpl struct {
@@ -32,7 +40,7 @@ type UserControllerImpl struct {
 // AddUser godoc
 //
 //	@Summary		Create a new user
-//	@Description	Create a new user with the provided data
+//	@Description	Create a new user with the provided data. New users are always assigned the USER role.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -43,38 +51,31 @@ type UserControllerImpl struct {
 //	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
 //	@Router			/users [post]
 func (u UserControllerImpl) AddUser(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	var request dao.User
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Info("Error parsing request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
-	}
-
-	if request.RoleID == 0 {
-		request.RoleID = 2
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(request); err != nil {
+	if err := pkg.ValidateStruct(request); err != nil {
 		log.Info("Error validating request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
-	user, err := u.userSvc.AddUser(request)
+	user, err := u.userSvc.AddUser(c.Request.Context(), request)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.UserResponse{
-		ID:     user.ID,
-		Email:  user.Email,
-		RoleID: user.RoleID,
+		ID:            user.ID,
+		Email:         user.Email,
+		RoleID:        user.RoleID,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MfaEnabledAt != nil,
 	}
 
 	c.JSON(http.StatusCreated, pkg.BuildResponse(constant.Success, response))
@@ -92,25 +93,20 @@ func (u UserControllerImpl) AddUser(c *gin.Context) {
 //	@Router			/users [get]
 //	@Security		BearerAuth
 func (u UserControllerImpl) GetAllUser(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
-	roleId := c.GetInt("roleId")
-	if roleId != 1 {
-		log.Info("Access denied. Not an Admin User")
-		pkg.PanicException(constant.Unauthorized)
-	}
-
-	users, err := u.userSvc.GetAllUser()
+	users, err := u.userSvc.GetAllUser(c.Request.Context())
 	if err != nil {
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := make([]dao.UserResponse, len(users))
 	for i, user := range users {
 		response[i] = dao.UserResponse{
-			ID:     user.ID,
-			Email:  user.Email,
-			RoleID: user.RoleID,
+			ID:            user.ID,
+			Email:         user.Email,
+			RoleID:        user.RoleID,
+			EmailVerified: user.EmailVerifiedAt != nil,
+			MfaEnabled:    user.MfaEnabledAt != nil,
 		}
 	}
 
@@ -131,29 +127,26 @@ func (u UserControllerImpl) GetAllUser(c *gin.Context) {
 //	@Router			/users/{id} [get]
 //	@Security		BearerAuth
 func (u UserControllerImpl) GetUserById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	pathUserId, _ := strconv.Atoi(c.Param("userId"))
 	userId := c.GetInt("userId")
 	if userId != pathUserId {
 		log.Info("Access denied. Not a resource owner")
-		pkg.PanicException(constant.Unauthorized)
+		pkg.AbortWithError(c, pkg.NewUnauthorizedError("Not a resource owner", nil))
+		return
 	}
 
-	user, err := u.userSvc.GetUserById(userId)
+	user, err := u.userSvc.GetUserById(c.Request.Context(), userId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.UserResponse{
-		ID:     user.ID,
-		Email:  user.Email,
-		RoleID: user.RoleID,
+		ID:            user.ID,
+		Email:         user.Email,
+		RoleID:        user.RoleID,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MfaEnabledAt != nil,
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
@@ -177,43 +170,39 @@ func (u UserControllerImpl) GetUserById(c *gin.Context) {
 //	@Router			/users/{id} [put]
 //	@Security		BearerAuth
 func (u UserControllerImpl) UpdateUserById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	pathUserId, _ := strconv.Atoi(c.Param("userId"))
 	userId := c.GetInt("userId")
 	if userId != pathUserId {
 		log.Info("Access denied. Not a resource owner")
-		pkg.PanicException(constant.Unauthorized)
+		pkg.AbortWithError(c, pkg.NewUnauthorizedError("Not a resource owner", nil))
+		return
 	}
 
 	var request dao.User
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Info("Error parsing request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
 	}
 
-	if request.Email != "" {
-		validate := validator.New()
-		if err := validate.Var(request.Email, "email"); err != nil {
-			log.Info("Error validating request data: ", err)
-			pkg.PanicException(constant.InvalidRequest)
-		}
+	if err := pkg.ValidatePartial(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
-	user, err := u.userSvc.UpdateUserById(request, userId)
+	user, err := u.userSvc.UpdateUserById(c.Request.Context(), request, userId)
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
-
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	response := dao.UserResponse{
-		ID:     user.ID,
-		Email:  user.Email,
-		RoleID: user.RoleID,
+		ID:            user.ID,
+		Email:         user.Email,
+		RoleID:        user.RoleID,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MfaEnabledAt != nil,
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
@@ -232,64 +221,389 @@ func (u UserControllerImpl) UpdateUserById(c *gin.Context) {
 //	@Router			/users/{id} [delete]
 //	@Security		BearerAuth
 func (u UserControllerImpl) DeleteUserById(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	pathUserId, _ := strconv.Atoi(c.Param("userId"))
 	userId := c.GetInt("userId")
 	if userId != pathUserId {
 		log.Info("Access denied. Not a resource owner")
-		pkg.PanicException(constant.Unauthorized)
+		pkg.AbortWithError(c, pkg.NewUnauthorizedError("Not a resource owner", nil))
+		return
 	}
 
-	err := u.userSvc.DeleteUserById(userId)
+	err := u.userSvc.DeleteUserById(c.Request.Context(), userId)
 	if err != nil {
-		pkg.PanicException(constant.UnknownError)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
 }
 
+// UpdateUserRoleById godoc
+//
+//	@Summary		Change user role by ID
+//	@Description	Assign an existing role to a user. Admin only. Requires JWT authentication.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int									true	"User ID"
+//	@Param			role	body		dao.UserRoleRequest					true	"Role assignment"
+//	@Success		200		{object}	dto.ApiResponse[dao.UserResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		404		{object}	dto.ApiResponse[any]				"Not found"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/users/{id}/role [put]
+//	@Security		BearerAuth
+func (u UserControllerImpl) UpdateUserRoleById(c *gin.Context) {
+	userId, _ := strconv.Atoi(c.Param("userId"))
+
+	var request dao.UserRoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	user, err := u.userSvc.UpdateUserRoleById(c.Request.Context(), userId, request.RoleID)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := dao.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		RoleID:        user.RoleID,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MfaEnabledAt != nil,
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
+// UnlockUserById godoc
+//
+//	@Summary		Unlock user by ID
+//	@Description	Lift the lockout of an account locked after repeated failed logins. Admin only. Requires JWT authentication.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		int									true	"User ID"
+//	@Success		200	{object}	dto.ApiResponse[dao.UserResponse]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		404	{object}	dto.ApiResponse[any]				"Not found"
+//	@Failure		500	{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/users/{id}/unlock [post]
+//	@Security		BearerAuth
+func (u UserControllerImpl) UnlockUserById(c *gin.Context) {
+	userId, _ := strconv.Atoi(c.Param("userId"))
+
+	user, err := u.userSvc.UnlockUserById(c.Request.Context(), userId)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	response := dao.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		RoleID:        user.RoleID,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MfaEnabledAt != nil,
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, response))
+}
+
+// GetLoginHistoryById godoc
+//
+//	@Summary		Get login history by user ID
+//	@Description	Retrieve the 20 most recent login attempts of the authenticated user, newest first. Requires JWT authentication.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		int										true	"User ID"
+//	@Success		200	{object}	dto.ApiResponse[[]dao.LoginAttempt]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]					"Unauthorized"
+//	@Failure		500	{object}	dto.ApiResponse[any]					"Internal server error"
+//	@Router			/users/{id}/logins [get]
+//	@Security		BearerAuth
+func (u UserControllerImpl) GetLoginHistoryById(c *gin.Context) {
+	pathUserId, _ := strconv.Atoi(c.Param("userId"))
+	userId := c.GetInt("userId")
+	if userId != pathUserId {
+		log.Info("Access denied. Not a resource owner")
+		pkg.AbortWithError(c, pkg.NewUnauthorizedError("Not a resource owner", nil))
+		return
+	}
+
+	attempts, err := u.userSvc.GetLoginHistoryById(c.Request.Context(), userId)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, attempts))
+}
+
 // LoginUser godoc
 //
 //	@Summary		Authenticate a user
-//	@Description	Authenticate a user with the provided credentials and return a JWT token
+//	@Description	Authenticate a user with the provided credentials and return a short-lived JWT access token and a refresh token. Users with MFA, or whose role requires it, get an MFA token to complete the login with instead. The account is temporarily locked after repeated failed logins, and a locked account is rejected as unauthorized.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
-//	@Param			user	body		dao.User				true	"User credentials"
-//	@Success		200		{object}	dto.ApiResponse[string]	"Success"
-//	@Failure		400		{object}	dto.ApiResponse[any]	"Bad request"
-//	@Failure		401		{object}	dto.ApiResponse[any]	"Unauthorized"
-//	@Failure		500		{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Param			user	body		dao.User							true	"User credentials"
+//	@Success		200		{object}	dto.ApiResponse[dao.LoginResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		429		{object}	dto.ApiResponse[any]				"Too many requests"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
 //	@Router			/users/login [post]
 func (u UserControllerImpl) LoginUser(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
 	var request dao.User
 	if err := c.ShouldBindJSON(&request); err != nil {
 		log.Info("Error parsing request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(request); err != nil {
+	if err := pkg.ValidateStruct(request); err != nil {
 		log.Info("Error validating request data: ", err)
-		pkg.PanicException(constant.InvalidRequest)
+		pkg.AbortWithError(c, err)
+		return
 	}
 
-	token, err := u.userSvc.LoginUser(request)
+	token, err := u.userSvc.LoginUser(c.Request.Context(), request, c.ClientIP())
 	if err != nil {
-		var customErr *pkg.CustomError
-		if errors.As(err, &customErr) {
-			pkg.PanicException(customErr.Type)
-		}
+		pkg.AbortWithError(c, err)
+		return
+	}
 
-		pkg.PanicException(constant.UnknownError)
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, token))
+}
+
+// VerifyMfaLogin godoc
+//
+//	@Summary		Complete an MFA login
+//	@Description	Complete a login with the MFA token returned by the login and a TOTP or recovery code. The MFA token expires after 5 minutes. If the login enrolled the user in MFA, their recovery codes are returned as well.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.MfaLoginRequest					true	"MFA token and code"
+//	@Success		200		{object}	dto.ApiResponse[dao.LoginResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		429		{object}	dto.ApiResponse[any]				"Too many requests"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/users/login/mfa [post]
+func (u UserControllerImpl) VerifyMfaLogin(c *gin.Context) {
+	var request dao.MfaLoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	token, err := u.userSvc.VerifyMfaLogin(c.Request.Context(), request.MfaToken, request.Code, c.ClientIP())
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, token))
+}
+
+// RefreshToken godoc
+//
+//	@Summary		Refresh an access token
+//	@Description	Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes the session.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	body		dao.RefreshTokenRequest				true	"Refresh token"
+//	@Success		200		{object}	dto.ApiResponse[dao.TokenResponse]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]				"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]				"Unauthorized"
+//	@Failure		500		{object}	dto.ApiResponse[any]				"Internal server error"
+//	@Router			/users/token/refresh [post]
+func (u UserControllerImpl) RefreshToken(c *gin.Context) {
+	var request dao.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	token, err := u.userSvc.RefreshToken(c.Request.Context(), request.RefreshToken)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, token))
 }
 
+// LogoutUser godoc
+//
+//	@Summary		Log out the current session
+//	@Description	Revoke the session of the access token, invalidating it and its refresh tokens. Requires JWT authentication.
+//	@Tags			users
+//	@Produce		json
+//	@Success		200	{object}	dto.ApiResponse[any]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]	"Unauthorized"
+//	@Failure		500	{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Router			/users/logout [post]
+//	@Security		BearerAuth
+func (u UserControllerImpl) LogoutUser(c *gin.Context) {
+	err := u.userSvc.LogoutUser(c.Request.Context(), c.GetString("sessionId"))
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
+// RequestPasswordReset godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Send a single-use, time-limited password reset token to the user's email. The response is the same whether or not the email is registered.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.PasswordResetRequest	true	"Account email"
+//	@Success		200		{object}	dto.ApiResponse[any]		"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]		"Bad request"
+//	@Failure		500		{object}	dto.ApiResponse[any]		"Internal server error"
+//	@Router			/users/password/reset [post]
+func (u UserControllerImpl) RequestPasswordReset(c *gin.Context) {
+	var request dao.PasswordResetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	err := u.userSvc.RequestPasswordReset(c.Request.Context(), request.Email)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Confirm a password reset
+//	@Description	Set a new password using a password reset token. The token can only be used once and all existing sessions of the user are revoked.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dao.PasswordResetConfirmRequest	true	"Reset token and new password"
+//	@Success		200		{object}	dto.ApiResponse[any]			"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]			"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]			"Unauthorized"
+//	@Failure		500		{object}	dto.ApiResponse[any]			"Internal server error"
+//	@Router			/users/password/reset/confirm [post]
+func (u UserControllerImpl) ResetPassword(c *gin.Context) {
+	var request dao.PasswordResetConfirmRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		log.Info("Error parsing request data: ", err)
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Invalid request data", err))
+		return
+	}
+
+	if err := pkg.ValidateStruct(request); err != nil {
+		log.Info("Error validating request data: ", err)
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	err := u.userSvc.ResetPassword(c.Request.Context(), request.Token, request.Password)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email address
+//	@Description	Confirm the user's email address with the token from the verification link
+//	@Tags			users
+//	@Produce		json
+//	@Param			token	query		string					true	"Verification token"
+//	@Success		200		{object}	dto.ApiResponse[any]	"Success"
+//	@Failure		400		{object}	dto.ApiResponse[any]	"Bad request"
+//	@Failure		401		{object}	dto.ApiResponse[any]	"Unauthorized"
+//	@Failure		500		{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Router			/users/verify [get]
+func (u UserControllerImpl) VerifyEmail(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		log.Info("Error parsing request data: missing token")
+		pkg.AbortWithError(c, pkg.NewInvalidRequestError("Missing token", nil))
+		return
+	}
+
+	err := u.userSvc.VerifyEmail(c.Request.Context(), token)
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
+// ResendVerification godoc
+//
+//	@Summary		Resend email verification
+//	@Description	Send a new verification link to the user's email. Limited to one request per minute and five per hour. Requires JWT authentication.
+//	@Tags			users
+//	@Produce		json
+//	@Success		200	{object}	dto.ApiResponse[any]	"Success"
+//	@Failure		401	{object}	dto.ApiResponse[any]	"Unauthorized"
+//	@Failure		409	{object}	dto.ApiResponse[any]	"Conflict"
+//	@Failure		429	{object}	dto.ApiResponse[any]	"Too many requests"
+//	@Failure		500	{object}	dto.ApiResponse[any]	"Internal server error"
+//	@Router			/users/verify/resend [post]
+//	@Security		BearerAuth
+func (u UserControllerImpl) ResendVerification(c *gin.Context) {
+	err := u.userSvc.ResendVerification(c.Request.Context(), c.GetInt("userId"))
+	if err != nil {
+		pkg.AbortWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg.BuildResponse(constant.Success, pkg.Null()))
+}
+
 func UserControllerInit(userService service.UserService) *UserControllerImpl {
 	return &UserControllerImpl{
 		userSvc: userService,
@@ -4,20 +4,43 @@ import "time"
 
 type Event struct {
 	ID          int       `gorm:"column:id; primary_key; not null" json:"-"`
-	Name        string    `gorm:"column:name; not null" json:"name" validate:"required"`
-	Description string    `gorm:"column:description; not null" json:"description" validate:"required"`
-	Location    string    `gorm:"column:location; not null" json:"location" validate:"required"`
-	EventTime   time.Time `gorm:"column:event_time; not null" json:"event_time" validate:"required"`
+	Name        string    `gorm:"column:name; not null; index:idx_events_fulltext,class:FULLTEXT" json:"name" validate:"required,max=100"`
+	Description string    `gorm:"column:description; not null; index:idx_events_fulltext,class:FULLTEXT" json:"description" validate:"required,max=2000"`
+	Location    string    `gorm:"column:location; not null; index:idx_events_fulltext,class:FULLTEXT" json:"location" validate:"required,max=255"`
+	EventTime   time.Time `gorm:"column:event_time; not null" json:"event_time" validate:"required,future"`
+	Capacity    int       `gorm:"column:capacity; not null; default:0" json:"capacity" validate:"min=0"`
 	UserID      int       `gorm:"column:user_id; not null" json:"-"`
 	User        User      `gorm:"foreignKey:UserID; references:ID" json:"-"`
 	BaseModel
 }
 
+// EventUpdateRequest holds the fields of a partial event update; unset fields are left unchanged.
+// Capacity is a pointer so that updating it to 0, which means unlimited, can be told apart from leaving it unset.
+type EventUpdateRequest struct {
+	Name        string    `json:"name" validate:"max=100"`
+	Description string    `json:"description" validate:"max=2000"`
+	Location    string    `json:"location" validate:"max=255"`
+	EventTime   time.Time `json:"event_time" validate:"future"`
+	Capacity    *int      `json:"capacity" validate:"min=0"`
+}
+
 type EventResponse struct {
 	ID          int       `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Location    string    `json:"location"`
 	EventTime   time.Time `json:"event_time"`
+	Capacity    int       `json:"capacity"`
 	UserID      int       `json:"user_id"`
 }
+
+type EventSearchResult struct {
+	Event
+	Score float64 `gorm:"column:score"`
+}
+
+type EventSearchResponse struct {
+	EventResponse
+	Score     float64           `json:"score"`
+	Highlight map[string]string `json:"highlight"`
+}
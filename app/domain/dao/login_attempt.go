@@ -0,0 +1,12 @@
+package dao
+
+import "time"
+
+type LoginAttempt struct {
+	ID        int       `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID    *int      `gorm:"column:user_id" json:"-"`
+	Email     string    `gorm:"column:email; type:varchar(255); not null" json:"-"`
+	IPAddress string    `gorm:"column:ip_address; type:varchar(45); not null" json:"ip_address"`
+	Success   bool      `gorm:"column:success; not null" json:"success"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+}
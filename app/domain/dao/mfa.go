@@ -0,0 +1,48 @@
+package dao
+
+import "time"
+
+type MfaChallenge struct {
+	ID        int        `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID    int        `gorm:"column:user_id; not null" json:"-"`
+	User      User       `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	TokenHash string     `gorm:"column:token_hash; type:char(64); not null; uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at; not null" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"-"`
+	BaseModel
+}
+
+type RecoveryCode struct {
+	ID       int        `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID   int        `gorm:"column:user_id; not null; index:idx_recovery_codes_user_code" json:"-"`
+	User     User       `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	CodeHash string     `gorm:"column:code_hash; type:char(64); not null; index:idx_recovery_codes_user_code" json:"-"`
+	UsedAt   *time.Time `gorm:"column:used_at" json:"-"`
+	BaseModel
+}
+
+type MfaCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MfaLoginRequest struct {
+	MfaToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+}
+
+type MfaEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LoginResponse struct {
+	*TokenResponse
+	MfaRequired   bool                   `json:"mfa_required,omitempty"`
+	MfaToken      string                 `json:"mfa_token,omitempty"`
+	MfaEnrollment *MfaEnrollmentResponse `json:"mfa_enrollment,omitempty"`
+	RecoveryCodes []string               `json:"recovery_codes,omitempty"`
+}
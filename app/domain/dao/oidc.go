@@ -0,0 +1,30 @@
+package dao
+
+import "time"
+
+type UserIdentity struct {
+	ID      int    `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID  int    `gorm:"column:user_id; not null" json:"-"`
+	User    User   `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	Issuer  string `gorm:"column:issuer; type:varchar(255); not null; uniqueIndex:idx_user_identities_issuer_subject" json:"-"`
+	Subject string `gorm:"column:subject; type:varchar(255); not null; uniqueIndex:idx_user_identities_issuer_subject" json:"-"`
+	BaseModel
+}
+
+type OidcState struct {
+	ID           int        `gorm:"column:id; primary_key; not null" json:"-"`
+	StateHash    string     `gorm:"column:state_hash; type:char(64); not null; uniqueIndex" json:"-"`
+	CodeVerifier string     `gorm:"column:code_verifier; type:varchar(128); not null" json:"-"`
+	Nonce        string     `gorm:"column:nonce; type:varchar(64); not null" json:"-"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at; not null" json:"-"`
+	UsedAt       *time.Time `gorm:"column:used_at" json:"-"`
+	BaseModel
+}
+
+// OidcLogin is the identity asserted by a verified ID token of the OpenID Connect provider.
+type OidcLogin struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Role    string
+}
@@ -0,0 +1,22 @@
+package dao
+
+import "time"
+
+type PasswordReset struct {
+	ID        int        `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID    int        `gorm:"column:user_id; not null" json:"-"`
+	User      User       `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	TokenHash string     `gorm:"column:token_hash; type:char(64); not null; uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at; not null" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"-"`
+	BaseModel
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
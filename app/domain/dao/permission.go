@@ -0,0 +1,7 @@
+package dao
+
+type Permission struct {
+	ID   int    `gorm:"column:id; primary_key; not null" json:"id"`
+	Name string `gorm:"column:name; type:varchar(100); not null; uniqueIndex" json:"name"`
+	BaseModel
+}
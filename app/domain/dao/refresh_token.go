@@ -0,0 +1,26 @@
+package dao
+
+import "time"
+
+type RefreshToken struct {
+	ID        int        `gorm:"column:id; primary_key; not null" json:"-"`
+	UserID    int        `gorm:"column:user_id; not null" json:"-"`
+	User      User       `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	FamilyID  string     `gorm:"column:family_id; type:varchar(64); not null; index" json:"-"`
+	TokenHash string     `gorm:"column:token_hash; type:char(64); not null; uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at; not null" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"-"`
+	RevokedAt *time.Time `gorm:"column:revoked_at" json:"-"`
+	BaseModel
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
@@ -1,10 +1,26 @@
 package dao
 
+const (
+	RegisterStatusConfirmed  = "confirmed"
+	RegisterStatusWaitlisted = "waitlisted"
+	RegisterStatusCancelled  = "cancelled"
+)
+
 type Register struct {
-	ID      int   `gorm:"column:id; primary_key; not null" json:"id"`
-	EventID int   `gorm:"column:event_id; not null; uniqueIndex:idx_event_user" json:"event_id"`
-	Event   Event `gorm:"foreignKey:EventID;references:ID" json:"-"`
-	UserID  int   `gorm:"column:user_id; not null; uniqueIndex:idx_event_user" json:"user_id"`
-	User    User  `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	ID       int    `gorm:"column:id; primary_key; not null" json:"id"`
+	EventID  int    `gorm:"column:event_id; not null; uniqueIndex:idx_event_user" json:"event_id"`
+	Event    Event  `gorm:"foreignKey:EventID;references:ID" json:"-"`
+	UserID   int    `gorm:"column:user_id; not null; uniqueIndex:idx_event_user" json:"user_id"`
+	User     User   `gorm:"foreignKey:UserID;references:ID" json:"-"`
+	Status   string `gorm:"column:status; type:varchar(20); not null; default:confirmed" json:"status"`
+	Position int    `gorm:"-" json:"position"`
 	BaseModel
 }
+
+type RegisterResponse struct {
+	ID       int    `json:"id"`
+	EventID  int    `json:"event_id"`
+	UserID   int    `json:"user_id"`
+	Status   string `json:"status"`
+	Position int    `json:"position"`
+}
@@ -1,7 +1,20 @@
 package dao
 
 type Role struct {
-	ID   int    `gorm:"column:id; primary_key; not null" json:"id"`
-	Role string `gorm:"column:role; not null" json:"role"`
+	ID          int          `gorm:"column:id; primary_key; not null" json:"id"`
+	Role        string       `gorm:"column:role; not null" json:"role"`
+	MfaRequired bool         `gorm:"column:mfa_required; not null; default:false" json:"mfa_required"`
+	Permissions []Permission `gorm:"many2many:role_permissions" json:"-"`
 	BaseModel
 }
+
+type RoleResponse struct {
+	ID          int      `json:"id"`
+	Role        string   `json:"role"`
+	MfaRequired bool     `json:"mfa_required"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleMfaRequest struct {
+	Required *bool `json:"required" validate:"required"`
+}
@@ -1,16 +1,30 @@
 package dao
 
+import "time"
+
 type User struct {
-	ID       int    `gorm:"column:id; primary_key; not null" json:"-"`
-	Email    string `gorm:"column:email; type:varchar(255); not null; uniqueIndex" json:"email" validate:"email"`
-	Password string `gorm:"column:password; not null" json:"password,omitempty" validate:"required"`
-	RoleID   int    `gorm:"column:role_id; not null" json:"role_id"`
-	Role     Role   `gorm:"foreignKey:RoleID; references:ID" json:"-"`
+	ID              int        `gorm:"column:id; primary_key; not null" json:"-"`
+	Email           string     `gorm:"column:email; type:varchar(255); not null; uniqueIndex" json:"email" validate:"email"`
+	Password        string     `gorm:"column:password; not null" json:"password,omitempty" validate:"required"`
+	RoleID          int        `gorm:"column:role_id; not null" json:"-"`
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"-"`
+	FailedLogins    int        `gorm:"column:failed_logins; not null; default:0" json:"-"`
+	LockedUntil     *time.Time `gorm:"column:locked_until" json:"-"`
+	MfaSecret       string     `gorm:"column:mfa_secret; type:varchar(255); not null; default:''" json:"-"`
+	MfaEnabledAt    *time.Time `gorm:"column:mfa_enabled_at" json:"-"`
+	MfaLastStep     int64      `gorm:"column:mfa_last_step; not null; default:0" json:"-"`
+	Role            Role       `gorm:"foreignKey:RoleID; references:ID" json:"-"`
 	BaseModel
 }
 
 type UserResponse struct {
-	ID     int    `json:"id"`
-	Email  string `json:"email"`
-	RoleID int    `json:"role_id"`
+	ID            int    `json:"id"`
+	Email         string `json:"email"`
+	RoleID        int    `json:"role_id"`
+	EmailVerified bool   `json:"email_verified"`
+	MfaEnabled    bool   `json:"mfa_enabled"`
+}
+
+type UserRoleRequest struct {
+	RoleID int `json:"role_id" validate:"required,min=1"`
 }
@@ -1,7 +1,16 @@
 package dto
 
 type ApiResponse[T any] struct {
-	ResponseKey     string `json:"response_key"`
-	ResponseMessage string `json:"response_message"`
-	Data            T      `json:"data"`
+	ResponseKey     string       `json:"response_key"`
+	ResponseMessage string       `json:"response_message"`
+	Data            T            `json:"data"`
+	Pagination      *Pagination  `json:"pagination,omitempty"`
+	Errors          []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
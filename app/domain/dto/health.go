@@ -0,0 +1,21 @@
+package dto
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// Health is the status of the application and, for readiness, of each of its dependencies.
+type Health struct {
+	Status  string                 `json:"status"`
+	Version string                 `json:"version"`
+	Commit  string                 `json:"commit"`
+	Checks  map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck is the status of a single dependency.
+type HealthCheck struct {
+	Status  string                 `json:"status"`
+	Error   string                 `json:"error,omitempty"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+type EventQuery struct {
+	Page     int       `form:"page" validate:"omitempty,min=1"`
+	Limit    int       `form:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor   string    `form:"cursor"`
+	Location string    `form:"location"`
+	UserID   int       `form:"user_id" validate:"omitempty,min=1"`
+	From     time.Time `form:"from"`
+	To       time.Time `form:"to"`
+	Upcoming bool      `form:"upcoming"`
+	Sort     string    `form:"sort" validate:"omitempty,oneof=event_time name"`
+	Order    string    `form:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+type EventSearchQuery struct {
+	Q     string `form:"q" validate:"required,max=100"`
+	Page  int    `form:"page" validate:"omitempty,min=1"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// namespace prefixes the name of every application metric.
+const namespace = "event_booking"
+
+// Metrics holds the Prometheus collectors of the application in its own registry,
+// so that each application instance, e.g. in tests, exposes only its own metrics.
+type Metrics struct {
+	registry               *prometheus.Registry
+	httpRequests           *prometheus.CounterVec
+	httpRequestDuration    *prometheus.HistogramVec
+	registrationsCreated   *prometheus.CounterVec
+	registrationsCancelled prometheus.Counter
+	eventsCreated          prometheus.Counter
+	failedLogins           prometheus.Counter
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveRequest records a handled HTTP request.
+// The route is the route template, e.g. "/api/events/:eventId", rather than the request path.
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	labels := prometheus.Labels{"method": method, "route": route, "status": strconv.Itoa(status)}
+
+	m.httpRequests.With(labels).Inc()
+	m.httpRequestDuration.With(labels).Observe(duration.Seconds())
+}
+
+// RegistrationCreated records a registration for an event with the given status, confirmed or waitlisted.
+func (m *Metrics) RegistrationCreated(status string) {
+	m.registrationsCreated.WithLabelValues(status).Inc()
+}
+
+// RegistrationCancelled records a registration cancelled by its user.
+func (m *Metrics) RegistrationCancelled() {
+	m.registrationsCancelled.Inc()
+}
+
+// EventCreated records a created event.
+func (m *Metrics) EventCreated() {
+	m.eventsCreated.Inc()
+}
+
+// LoginFailed records a login rejected because of invalid credentials.
+func (m *Metrics) LoginFailed() {
+	m.failedLogins.Inc()
+}
+
+func MetricsInit(db *gorm.DB) *Metrics {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Error getting database connection: ", err)
+	}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of handled HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latency of handled HTTP requests by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		registrationsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "registrations_created_total",
+			Help:      "Number of event registrations by status, confirmed or waitlisted.",
+		}, []string{"status"}),
+		registrationsCancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "registrations_cancelled_total",
+			Help:      "Number of event registrations cancelled by their user.",
+		}),
+		eventsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "events_created_total",
+			Help:      "Number of created events.",
+		}),
+		failedLogins: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "failed_logins_total",
+			Help:      "Number of logins rejected because of invalid credentials.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(sqlDB, "event_booking"),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.registrationsCreated,
+		m.registrationsCancelled,
+		m.eventsCreated,
+		m.failedLogins,
+	)
+
+	return m
+}
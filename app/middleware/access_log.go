@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"event-booking-api/app/pkg"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// AccessLog logs each handled request through the request-scoped logger, so that access log lines share
+// the format, request ID and trace IDs of the application logs. Only the path is logged, since query
+// strings may carry tokens. Server errors are logged at error level, client errors at warning level
+// and other requests at info level. Every request is logged, whatever the level of the application logs.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		status := c.Writer.Status()
+		fields := log.Fields{
+			"method":     c.Request.Method,
+			"route":      route,
+			"path":       c.Request.URL.Path,
+			"status":     status,
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  c.ClientIP(),
+			"bytes":      max(c.Writer.Size(), 0),
+		}
+		if userId, ok := c.Get("userId"); ok {
+			fields["user_id"] = userId
+		}
+
+		logger := pkg.Logger(c.Request.Context()).WithFields(fields)
+		logger.Logger = accessLogger(logger.Logger)
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error("Request handled")
+		case status >= http.StatusBadRequest:
+			logger.Warn("Request handled")
+		default:
+			logger.Info("Request handled")
+		}
+	}
+}
+
+// accessLogger returns a logger writing like the given one, but at least at info level.
+func accessLogger(logger *log.Logger) *log.Logger {
+	if logger.IsLevelEnabled(log.InfoLevel) {
+		return logger
+	}
+
+	return &log.Logger{
+		Out:          logger.Out,
+		Hooks:        logger.Hooks,
+		Formatter:    logger.Formatter,
+		ReportCaller: logger.ReportCaller,
+		Level:        log.InfoLevel,
+		ExitFunc:     logger.ExitFunc,
+		BufferPool:   logger.BufferPool,
+	}
+}
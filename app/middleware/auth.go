@@ -1,56 +1,58 @@
 package middleware
 
 import (
-	"errors"
-	"event-booking-api/app/constant"
 	"event-booking-api/app/pkg"
-	"os"
+	"event-booking-api/app/service"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func Auth(c *gin.Context) {
-	defer pkg.PanicHandler(c)
-
-	authHeader := c.Request.Header.Get("Authorization")
-	if authHeader == "" {
-		pkg.PanicException(constant.Unauthorized)
-	}
-
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		pkg.PanicException(constant.Unauthorized)
-	}
+// Auth only lets requests through that carry a valid access token whose session has not been revoked.
+func Auth(tokenSvc service.TokenService, userSvc service.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.Request.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			pkg.AbortWithError(c, pkg.NewUnauthorizedError("Missing bearer token", nil))
+			return
+		}
 
-	token := strings.TrimPrefix(authHeader, "Bearer ")
+		token := strings.TrimPrefix(authHeader, "Bearer ")
 
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, errors.New("unexpected signing method")
+		claims, err := tokenSvc.ParseToken(token)
+		if err != nil {
+			pkg.Logger(c.Request.Context()).Info("Error parsing token: ", err)
+			pkg.AbortWithError(c, pkg.NewUnauthorizedError("Invalid token", err))
+			return
 		}
 
-		return []byte(os.Getenv("JWT_SECRET_KEY")), nil
-	})
-	if err != nil {
-		pkg.PanicException(constant.Unauthorized)
-	}
+		sessionId, _ := claims["sid"].(string)
+		userId, userOk := claims["user_id"].(float64)
+		roleId, roleOk := claims["role_id"].(float64)
+		if sessionId == "" || !userOk || !roleOk {
+			pkg.AbortWithError(c, pkg.NewUnauthorizedError("Invalid token claims", nil))
+			return
+		}
 
-	if !parsedToken.Valid {
-		pkg.PanicException(constant.Unauthorized)
-	}
+		active, err := userSvc.IsSessionActive(c.Request.Context(), sessionId)
+		if err != nil {
+			pkg.AbortWithError(c, err)
+			return
+		}
 
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
-	if !ok {
-		pkg.PanicException(constant.Unauthorized)
-	}
+		if !active {
+			pkg.Logger(c.Request.Context()).Info("Access denied. Session revoked: ", sessionId)
+			pkg.AbortWithError(c, pkg.NewUnauthorizedError("Session revoked", nil))
+			return
+		}
 
-	userId := int(claims["user_id"].(float64))
-	roleId := int(claims["role_id"].(float64))
+		c.Set("userId", int(userId))
+		c.Set("roleId", int(roleId))
+		c.Set("sessionId", sessionId)
 
-	c.Set("userId", userId)
-	c.Set("roleId", roleId)
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(pkg.ContextWithLogger(ctx, pkg.Logger(ctx).WithField("user_id", int(userId))))
 
-	c.Next()
+		c.Next()
+	}
 }
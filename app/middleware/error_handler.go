@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/constant"
+	"event-booking-api/app/pkg"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var responseStatusCodes = map[constant.ResponseStatus]int{
+	constant.InvalidRequest:  http.StatusBadRequest,
+	constant.Unauthorized:    http.StatusUnauthorized,
+	constant.DataNotFound:    http.StatusNotFound,
+	constant.Conflict:        http.StatusConflict,
+	constant.TooManyRequests: http.StatusTooManyRequests,
+	constant.Timeout:         http.StatusGatewayTimeout,
+	constant.UnknownError:    http.StatusInternalServerError,
+}
+
+// statusClientClosedRequest is the non-standard status recorded for requests abandoned by the client.
+const statusClientClosedRequest = 499
+
+// ErrorHandler renders the last error recorded with c.Error by a handler as a dto.ApiResponse.
+// A *pkg.CustomError keeps its specific message and field errors; unrecognised errors are logged and reported
+// as an unknown error without exposing their detail to the client.
+// Requests canceled by the client get no response body, since nobody is left to read it.
+func ErrorHandler(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	if errors.Is(err, context.Canceled) {
+		pkg.Logger(c.Request.Context()).Debug("Request canceled by client: ", err)
+		c.Status(statusClientClosedRequest)
+		return
+	}
+
+	customErr := toCustomError(c.Request.Context(), err)
+
+	status, ok := responseStatusCodes[customErr.Type]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	response := pkg.BuildResponse_(customErr.Type.GetResponseStatus(), customErr.Msg, pkg.Null())
+	response.Errors = customErr.Details
+
+	c.JSON(status, response)
+}
+
+// toCustomError maps err to the *pkg.CustomError describing it, recognising wrapped gorm and context errors.
+func toCustomError(ctx context.Context, err error) *pkg.CustomError {
+	var customErr *pkg.CustomError
+	switch {
+	case errors.As(err, &customErr):
+		return customErr
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return pkg.NewNotFoundError(constant.DataNotFound.GetResponseMessage(), err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return pkg.NewConflictError(constant.Conflict.GetResponseMessage(), err)
+	case errors.Is(err, context.DeadlineExceeded):
+		pkg.Logger(ctx).Warn("Request timed out: ", err)
+		return pkg.NewCustomError(constant.Timeout, constant.Timeout.GetResponseMessage(), err)
+	default:
+		pkg.Logger(ctx).Error("Unhandled error: ", err)
+		return pkg.NewCustomError(constant.UnknownError, constant.UnknownError.GetResponseMessage(), err)
+	}
+}
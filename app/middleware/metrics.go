@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"event-booking-api/app/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that match no route, so that arbitrary paths do not create new series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of each request, labelled by route template and response status.
+// It must run before Recovery and ErrorHandler so that it observes the status they write.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		m.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
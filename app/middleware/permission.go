@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/service"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequirePermission only lets requests through whose role, as set by Auth, is granted the permission.
+func RequirePermission(roleSvc service.RoleService, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, err := roleSvc.HasPermission(c.Request.Context(), c.GetInt("roleId"), permission)
+		if err != nil {
+			pkg.AbortWithError(c, err)
+			return
+		}
+
+		if !allowed {
+			log.Info("Access denied. Missing permission: ", permission)
+			pkg.AbortWithError(c, pkg.NewUnauthorizedError("Missing permission "+permission, nil))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"event-booking-api/app/constant"
+	"event-booking-api/app/pkg"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKey identifies the client whose requests are counted together.
+type RateLimitKey func(c *gin.Context) string
+
+// ByIP counts the requests of each client IP address.
+func ByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// ByUser counts the requests of each authenticated user, falling back to the client IP address.
+// It must run after Auth.
+func ByUser(c *gin.Context) string {
+	if userId, ok := c.Get("userId"); ok {
+		return fmt.Sprintf("user:%v", userId)
+	}
+
+	return ByIP(c)
+}
+
+// RateLimit limits the requests of each client to the routes of a group, sharing one token bucket per client
+// and group. Requests over the limit are rejected with 429 and a Retry-After header. Requests are let through
+// if the store fails, so that an unavailable shared store does not take the API down.
+func RateLimit(store pkg.RateLimitStore, group string, limit pkg.RateLimit, key RateLimitKey) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit.Requests == 0 {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		allowed, retryAfter, err := store.Take(ctx, group+":"+key(c), limit)
+		if err != nil {
+			pkg.Logger(ctx).Error("Error checking rate limit: ", err)
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			pkg.Logger(ctx).Info("Rate limit exceeded for group ", group)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			pkg.AbortWithError(c, pkg.NewTooManyRequestsError(constant.TooManyRequests.GetResponseMessage(), nil))
+			return
+		}
+
+		c.Next()
+	}
+}
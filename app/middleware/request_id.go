@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"event-booking-api/app/pkg"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const requestIdHeader = "X-Request-ID"
+
+// requestIdPattern restricts incoming request IDs, so that clients cannot inject arbitrary text into the logs.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID assigns each request an ID, taken from a well-formed X-Request-ID header or generated otherwise,
+// and echoes it in the response. The ID is added to the request-scoped logger returned by pkg.Logger.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(requestIdHeader)
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+
+		c.Set("requestId", requestId)
+		c.Header(requestIdHeader, requestId)
+
+		ctx := pkg.ContextWithLogger(c.Request.Context(), log.WithField("request_id", requestId))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"event-booking-api/app/tracing"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// untracedRoutes are polled by orchestrators and scrapers and would only add noise to the traces.
+var untracedRoutes = []string{"/healthz", "/readyz", "/metrics"}
+
+// Tracing starts a span for each request, named after its route template. The span continues the trace
+// of an incoming W3C traceparent header and is passed to the handlers through the request context.
+func Tracing() gin.HandlerFunc {
+	return otelgin.Middleware(tracing.ServiceName, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		return !slices.Contains(untracedRoutes, c.FullPath())
+	}))
+}
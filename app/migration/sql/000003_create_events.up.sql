@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `events` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` longtext NOT NULL,
+  `description` longtext NOT NULL,
+  `location` longtext NOT NULL,
+  `event_time` datetime(3) NOT NULL,
+  `user_id` bigint NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `fk_events_user` (`user_id`),
+  CONSTRAINT `fk_events_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE `events`
+  ADD COLUMN `capacity` bigint NOT NULL DEFAULT '0' AFTER `event_time`;
@@ -0,0 +1,2 @@
+ALTER TABLE `registers`
+  DROP COLUMN `status`;
@@ -0,0 +1,2 @@
+ALTER TABLE `registers`
+  ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'confirmed' AFTER `user_id`;
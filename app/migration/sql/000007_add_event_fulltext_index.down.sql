@@ -0,0 +1,2 @@
+ALTER TABLE `events`
+  DROP INDEX `idx_events_fulltext`;
@@ -0,0 +1,2 @@
+ALTER TABLE `events`
+  ADD FULLTEXT KEY `idx_events_fulltext` (`name`,`description`,`location`);
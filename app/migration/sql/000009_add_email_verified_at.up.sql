@@ -0,0 +1,5 @@
+ALTER TABLE `users`
+  ADD COLUMN `email_verified_at` datetime(3) DEFAULT NULL AFTER `role_id`;
+
+-- Users who signed up before email verification existed could not verify their email, so they are treated as verified.
+UPDATE `users` SET `email_verified_at` = COALESCE(`created_at`, NOW(3));
@@ -0,0 +1,9 @@
+DELETE FROM `role_permissions` WHERE `permission_id` = 7;
+
+DELETE FROM `permissions` WHERE `id` = 7;
+
+DROP TABLE IF EXISTS `login_attempts`;
+
+ALTER TABLE `users`
+  DROP COLUMN `locked_until`,
+  DROP COLUMN `failed_logins`;
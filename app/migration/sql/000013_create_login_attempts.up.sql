@@ -0,0 +1,19 @@
+ALTER TABLE `users`
+  ADD COLUMN `failed_logins` bigint NOT NULL DEFAULT 0,
+  ADD COLUMN `locked_until` datetime(3) DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` bigint DEFAULT NULL,
+  `email` varchar(255) NOT NULL,
+  `ip_address` varchar(45) NOT NULL,
+  `success` tinyint(1) NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_login_attempts_user_created` (`user_id`,`created_at`),
+  CONSTRAINT `fk_login_attempts_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+INSERT IGNORE INTO `permissions` (`id`, `name`) VALUES (7,'users:unlock');
+
+INSERT IGNORE INTO `role_permissions` (`role_id`, `permission_id`) VALUES (1,7);
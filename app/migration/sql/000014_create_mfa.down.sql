@@ -0,0 +1,15 @@
+DELETE FROM `role_permissions` WHERE `permission_id` = 8;
+
+DELETE FROM `permissions` WHERE `id` = 8;
+
+DROP TABLE IF EXISTS `recovery_codes`;
+
+DROP TABLE IF EXISTS `mfa_challenges`;
+
+ALTER TABLE `users`
+  DROP COLUMN `mfa_last_step`,
+  DROP COLUMN `mfa_enabled_at`,
+  DROP COLUMN `mfa_secret`;
+
+ALTER TABLE `roles`
+  DROP COLUMN `mfa_required`;
@@ -0,0 +1,39 @@
+ALTER TABLE `roles`
+  ADD COLUMN `mfa_required` tinyint(1) NOT NULL DEFAULT 0;
+
+ALTER TABLE `users`
+  ADD COLUMN `mfa_secret` varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN `mfa_enabled_at` datetime(3) DEFAULT NULL,
+  ADD COLUMN `mfa_last_step` bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS `mfa_challenges` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_mfa_challenges_token_hash` (`token_hash`),
+  KEY `fk_mfa_challenges_user` (`user_id`),
+  CONSTRAINT `fk_mfa_challenges_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+CREATE TABLE IF NOT EXISTS `recovery_codes` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL,
+  `code_hash` char(64) NOT NULL,
+  `used_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  `deleted_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_recovery_codes_user_code` (`user_id`,`code_hash`),
+  CONSTRAINT `fk_recovery_codes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+INSERT IGNORE INTO `permissions` (`id`, `name`) VALUES (8,'roles:manage');
+
+INSERT IGNORE INTO `role_permissions` (`role_id`, `permission_id`) VALUES (1,8);
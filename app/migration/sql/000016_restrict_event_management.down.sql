@@ -0,0 +1,9 @@
+DELETE `rp` FROM `role_permissions` `rp`
+  JOIN `permissions` `p` ON `p`.`id` = `rp`.`permission_id`
+  WHERE `p`.`name` = 'attendees:read';
+
+DELETE FROM `permissions` WHERE `name` = 'attendees:read';
+
+INSERT IGNORE INTO `role_permissions` (`role_id`, `permission_id`)
+  SELECT `r`.`id`, `p`.`id` FROM `roles` `r` JOIN `permissions` `p`
+  WHERE `r`.`role` = 'ORGANIZER' AND `p`.`name` = 'events:manage';
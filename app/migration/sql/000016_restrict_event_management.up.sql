@@ -0,0 +1,10 @@
+DELETE `rp` FROM `role_permissions` `rp`
+  JOIN `roles` `r` ON `r`.`id` = `rp`.`role_id`
+  JOIN `permissions` `p` ON `p`.`id` = `rp`.`permission_id`
+  WHERE `r`.`role` = 'ORGANIZER' AND `p`.`name` = 'events:manage';
+
+INSERT IGNORE INTO `permissions` (`name`) VALUES ('attendees:read');
+
+INSERT IGNORE INTO `role_permissions` (`role_id`, `permission_id`)
+  SELECT `r`.`id`, `p`.`id` FROM `roles` `r` JOIN `permissions` `p`
+  WHERE `r`.`role` IN ('ADMIN', 'ORGANIZER') AND `p`.`name` = 'attendees:read';
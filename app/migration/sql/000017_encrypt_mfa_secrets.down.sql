@@ -0,0 +1,10 @@
+-- The previous version reads TOTP secrets in plain and cannot use the encrypted ones, and clearing them would
+-- silently turn MFA off. Reverting is refused while encrypted secrets are stored, which are longer than 64 characters.
+ALTER TABLE `users`
+  ADD CONSTRAINT `chk_users_mfa_secret_not_encrypted` CHECK (CHAR_LENGTH(`mfa_secret`) <= 64);
+
+ALTER TABLE `users`
+  DROP CHECK `chk_users_mfa_secret_not_encrypted`;
+
+ALTER TABLE `users`
+  MODIFY COLUMN `mfa_secret` varchar(64) NOT NULL DEFAULT '';
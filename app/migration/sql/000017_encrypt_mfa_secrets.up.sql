@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+  MODIFY COLUMN `mfa_secret` varchar(255) NOT NULL DEFAULT '';
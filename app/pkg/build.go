@@ -0,0 +1,30 @@
+package pkg
+
+import "runtime/debug"
+
+// Version and Commit identify the build. They are set at link time, e.g.
+//
+//	go build -ldflags "-X event-booking-api/app/pkg.Version=1.2.0 -X event-booking-api/app/pkg.Commit=$(git rev-parse HEAD)"
+//
+// Commit falls back to the VCS revision recorded by the Go toolchain.
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+// BuildCommit returns the commit the binary was built from, or "unknown".
+func BuildCommit() string {
+	if Commit != "" {
+		return Commit
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,436 @@
+package pkg
+
+import (
+	"errors"
+	"event-booking-api/app/constant"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// minSecretKeyLength is the minimum length of JWT_SECRET_KEY, matching the 256-bit output of HS256.
+const minSecretKeyLength = 32
+
+// Config is the application configuration, loaded once at startup by LoadConfig.
+type Config struct {
+	LogLevel  string          `yaml:"log_level"`
+	LogFormat string          `yaml:"log_format"`
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	Notifier  NotifierConfig  `yaml:"notifier"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	OIDC      OIDCConfig      `yaml:"oidc"`
+	MFA       MFAConfig       `yaml:"mfa"`
+}
+
+type ServerConfig struct {
+	Port              string        `yaml:"port"`
+	BaseURL           string        `yaml:"base_url"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	TrustedProxies    []string      `yaml:"trusted_proxies"`
+}
+
+type DatabaseConfig struct {
+	DSN     string        `yaml:"dsn"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+type JWTConfig struct {
+	SecretKey        string    `yaml:"secret_key"`
+	KeysFile         string    `yaml:"keys_file"`
+	HS256AcceptUntil time.Time `yaml:"hs256_accept_until"`
+}
+
+// MFAConfig holds the key the TOTP secrets of the users are encrypted with at rest.
+type MFAConfig struct {
+	EncryptionKey string `yaml:"encryption_key"`
+}
+
+type NotifierConfig struct {
+	Type string `yaml:"type"`
+	File string `yaml:"file"`
+}
+
+// RateLimitConfig holds the limits of the rate limited route groups.
+type RateLimitConfig struct {
+	Auth    RateLimit `yaml:"auth"`
+	Booking RateLimit `yaml:"booking"`
+}
+
+// RateLimit allows bursts of up to Requests requests, refilled at Requests per Period.
+// A limit of 0 requests disables rate limiting.
+type RateLimit struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `yaml:"exporter"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+}
+
+// OIDCConfig configures login through an OpenID Connect provider. It is disabled while IssuerURL is empty.
+type OIDCConfig struct {
+	IssuerURL    string            `yaml:"issuer_url"`
+	ClientID     string            `yaml:"client_id"`
+	ClientSecret string            `yaml:"client_secret"`
+	RedirectURL  string            `yaml:"redirect_url"`
+	Scopes       []string          `yaml:"scopes"`
+	GroupsClaim  string            `yaml:"groups_claim"`
+	RoleMapping  []OIDCRoleMapping `yaml:"role_mapping"`
+}
+
+// OIDCRoleMapping assigns Role to the members of the provider group Group. The first matching mapping wins.
+type OIDCRoleMapping struct {
+	Group string `yaml:"group"`
+	Role  string `yaml:"role"`
+}
+
+// Enabled reports whether login through the OpenID Connect provider is configured.
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+// LoadConfig builds the configuration from, in increasing order of precedence:
+//   - the defaults below;
+//   - the YAML file at CONFIG_FILE, or config.yaml if it exists;
+//   - the variables of the .env file, if it exists;
+//   - the environment.
+//
+// It returns the Config and an error listing every invalid setting.
+func LoadConfig() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("loading .env: %w", err)
+	}
+
+	cfg := &Config{
+		LogLevel:  "WARN",
+		LogFormat: "text",
+		Server: ServerConfig{
+			Port:              "8080",
+			BaseURL:           "http://localhost:8080",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Timeout: 10 * time.Second,
+		},
+		Notifier: NotifierConfig{
+			Type: "log",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimitConfig{
+			Auth:    RateLimit{Requests: 20, Period: time.Minute},
+			Booking: RateLimit{Requests: 20, Period: time.Minute},
+		},
+		OIDC: OIDCConfig{
+			Scopes:      []string{"openid", "email", "profile"},
+			GroupsClaim: "groups",
+		},
+	}
+
+	path, required := os.LookupEnv("CONFIG_FILE")
+	if !required {
+		path = "config.yaml"
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	case required || !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// applyEnv overrides the settings whose environment variable is set.
+func (c *Config) applyEnv() error {
+	var errs []error
+
+	setString := func(target *string, key string) {
+		if value, ok := os.LookupEnv(key); ok {
+			*target = value
+		}
+	}
+
+	setInt := func(target *int, key string) {
+		if value, ok := os.LookupEnv(key); ok {
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+			*target = number
+		}
+	}
+
+	setDuration := func(target *time.Duration, key string) {
+		if value, ok := os.LookupEnv(key); ok {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			}
+			*target = duration
+		}
+	}
+
+	setString(&c.LogLevel, "LOG_LEVEL")
+	setString(&c.LogFormat, "LOG_FORMAT")
+	setString(&c.Server.Port, "PORT")
+	setString(&c.Server.BaseURL, "APP_BASE_URL")
+	setString(&c.Database.DSN, "DB_DSN")
+	setString(&c.JWT.SecretKey, "JWT_SECRET_KEY")
+	setString(&c.JWT.KeysFile, "JWT_KEYS_FILE")
+	setString(&c.Notifier.Type, "NOTIFIER")
+	setString(&c.Notifier.File, "NOTIFIER_FILE")
+	setString(&c.Tracing.Exporter, "TRACING_EXPORTER")
+	setString(&c.Tracing.OTLPEndpoint, "TRACING_OTLP_ENDPOINT")
+	setString(&c.OIDC.IssuerURL, "OIDC_ISSUER_URL")
+	setString(&c.OIDC.ClientID, "OIDC_CLIENT_ID")
+	setString(&c.OIDC.ClientSecret, "OIDC_CLIENT_SECRET")
+	setString(&c.OIDC.RedirectURL, "OIDC_REDIRECT_URL")
+	setString(&c.OIDC.GroupsClaim, "OIDC_GROUPS_CLAIM")
+	setString(&c.MFA.EncryptionKey, "MFA_ENCRYPTION_KEY")
+
+	setDuration(&c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	setDuration(&c.Server.ReadHeaderTimeout, "SERVER_READ_HEADER_TIMEOUT")
+	setDuration(&c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	setDuration(&c.Server.IdleTimeout, "SERVER_IDLE_TIMEOUT")
+	setDuration(&c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	setDuration(&c.Database.Timeout, "DB_TIMEOUT")
+
+	setInt(&c.RateLimit.Auth.Requests, "RATE_LIMIT_AUTH_REQUESTS")
+	setDuration(&c.RateLimit.Auth.Period, "RATE_LIMIT_AUTH_PERIOD")
+	setInt(&c.RateLimit.Booking.Requests, "RATE_LIMIT_BOOKING_REQUESTS")
+	setDuration(&c.RateLimit.Booking.Period, "RATE_LIMIT_BOOKING_PERIOD")
+
+	if value, ok := os.LookupEnv("SERVER_TRUSTED_PROXIES"); ok {
+		c.Server.TrustedProxies = splitList(value)
+	}
+
+	if value, ok := os.LookupEnv("OIDC_SCOPES"); ok {
+		c.OIDC.Scopes = splitList(value)
+	}
+
+	if value, ok := os.LookupEnv("OIDC_ROLE_MAPPING"); ok {
+		c.OIDC.RoleMapping = nil
+		for _, entry := range splitList(value) {
+			group, role, found := strings.Cut(entry, "=")
+			if !found {
+				errs = append(errs, fmt.Errorf("OIDC_ROLE_MAPPING: %q is not a group=ROLE pair", entry))
+				continue
+			}
+			c.OIDC.RoleMapping = append(c.OIDC.RoleMapping, OIDCRoleMapping{
+				Group: strings.TrimSpace(group),
+				Role:  strings.TrimSpace(role),
+			})
+		}
+	}
+
+	if value, ok := os.LookupEnv("TRACING_SAMPLE_RATIO"); ok {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO: %w", err))
+		}
+		c.Tracing.SampleRatio = ratio
+	}
+
+	if value, ok := os.LookupEnv("JWT_HS256_ACCEPT_UNTIL"); ok && value != "" {
+		until, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("JWT_HS256_ACCEPT_UNTIL: %w", err))
+		}
+		c.JWT.HS256AcceptUntil = until
+	}
+
+	return errors.Join(errs...)
+}
+
+// splitList splits a comma-separated environment variable, dropping blank entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// validate checks every setting, so that a misconfigured deployment fails at startup rather than on first use.
+func (c *Config) validate() error {
+	var errs []error
+
+	if !slices.Contains([]string{"TRACE", "DEBUG", "INFO", "WARN"}, c.LogLevel) {
+		errs = append(errs, errors.New("LOG_LEVEL must be one of TRACE, DEBUG, INFO or WARN"))
+	}
+
+	if !slices.Contains([]string{"text", "json"}, c.LogFormat) {
+		errs = append(errs, errors.New("LOG_FORMAT must be text or json"))
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		errs = append(errs, errors.New("PORT must be a port number"))
+	}
+
+	if baseURL, err := url.Parse(c.Server.BaseURL); err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		errs = append(errs, errors.New("APP_BASE_URL must be an absolute URL"))
+	}
+
+	timeouts := []struct {
+		key   string
+		value time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", c.Server.ReadTimeout},
+		{"SERVER_READ_HEADER_TIMEOUT", c.Server.ReadHeaderTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"SERVER_SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", timeout.key))
+		}
+	}
+
+	for _, proxy := range c.Server.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				errs = append(errs, fmt.Errorf("SERVER_TRUSTED_PROXIES: %q is not an IP address or CIDR range", proxy))
+			}
+		}
+	}
+
+	if c.Server.WriteTimeout <= c.Database.Timeout {
+		errs = append(errs, errors.New("SERVER_WRITE_TIMEOUT must be longer than DB_TIMEOUT"))
+	}
+
+	if c.Database.DSN == "" {
+		errs = append(errs, errors.New("DB_DSN is required"))
+	}
+
+	if c.Database.Timeout <= 0 {
+		errs = append(errs, errors.New("DB_TIMEOUT must be positive"))
+	}
+
+	if c.JWT.SecretKey == "" && c.JWT.KeysFile == "" {
+		errs = append(errs, errors.New("JWT_KEYS_FILE or JWT_SECRET_KEY is required"))
+	}
+
+	if c.JWT.SecretKey != "" && c.JWT.KeysFile != "" && c.JWT.HS256AcceptUntil.IsZero() {
+		errs = append(errs, errors.New("JWT_HS256_ACCEPT_UNTIL is required when both JWT_KEYS_FILE and JWT_SECRET_KEY are set"))
+	}
+
+	if c.JWT.SecretKey != "" && len(c.JWT.SecretKey) < minSecretKeyLength {
+		errs = append(errs, fmt.Errorf("JWT_SECRET_KEY must be at least %d characters long", minSecretKeyLength))
+	}
+
+	switch c.Notifier.Type {
+	case "log":
+	case "file":
+		if c.Notifier.File == "" {
+			errs = append(errs, errors.New("NOTIFIER_FILE is required for the file notifier"))
+		}
+	default:
+		errs = append(errs, errors.New("NOTIFIER must be log or file"))
+	}
+
+	rateLimits := []struct {
+		key   string
+		value RateLimit
+	}{
+		{"RATE_LIMIT_AUTH", c.RateLimit.Auth},
+		{"RATE_LIMIT_BOOKING", c.RateLimit.Booking},
+	}
+	for _, rateLimit := range rateLimits {
+		if rateLimit.value.Requests < 0 {
+			errs = append(errs, fmt.Errorf("%s_REQUESTS must not be negative", rateLimit.key))
+		}
+		if rateLimit.value.Requests > 0 && rateLimit.value.Period <= 0 {
+			errs = append(errs, fmt.Errorf("%s_PERIOD must be positive", rateLimit.key))
+		}
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if endpoint := c.Tracing.OTLPEndpoint; endpoint != "" {
+			if endpointURL, err := url.Parse(endpoint); err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+				errs = append(errs, errors.New("TRACING_OTLP_ENDPOINT must be an absolute URL"))
+			}
+		}
+	default:
+		errs = append(errs, errors.New("TRACING_EXPORTER must be none, stdout or otlp"))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	if c.OIDC.Enabled() {
+		if issuerURL, err := url.Parse(c.OIDC.IssuerURL); err != nil || issuerURL.Scheme == "" || issuerURL.Host == "" {
+			errs = append(errs, errors.New("OIDC_ISSUER_URL must be an absolute URL"))
+		}
+
+		if c.OIDC.ClientID == "" {
+			errs = append(errs, errors.New("OIDC_CLIENT_ID is required for OpenID Connect login"))
+		}
+
+		if redirect := c.OIDC.RedirectURL; redirect != "" {
+			if redirectURL, err := url.Parse(redirect); err != nil || redirectURL.Scheme == "" || redirectURL.Host == "" {
+				errs = append(errs, errors.New("OIDC_REDIRECT_URL must be an absolute URL"))
+			}
+		}
+
+		if !slices.Contains(c.OIDC.Scopes, "openid") {
+			errs = append(errs, errors.New("OIDC_SCOPES must include openid"))
+		}
+
+		if c.OIDC.GroupsClaim == "" {
+			errs = append(errs, errors.New("OIDC_GROUPS_CLAIM is required for OpenID Connect login"))
+		}
+
+		for _, mapping := range c.OIDC.RoleMapping {
+			if mapping.Group == "" {
+				errs = append(errs, errors.New("OIDC_ROLE_MAPPING: group must not be empty"))
+			}
+			if !slices.Contains([]string{constant.RoleAdmin, constant.RoleOrganizer, constant.RoleUser}, mapping.Role) {
+				errs = append(errs, fmt.Errorf("OIDC_ROLE_MAPPING: role of group %q must be one of ADMIN, ORGANIZER or USER", mapping.Group))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,39 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Cursor is the position after the last returned row, in the sort field and order it was returned in.
+type Cursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// EncodeCursor encodes the sort field and order of the page, and the sort value and ID of its last row,
+// into an opaque cursor.
+func EncodeCursor(sort, order, value string, id int) string {
+	data, _ := json.Marshal(Cursor{Sort: sort, Order: order, Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes an opaque cursor created by EncodeCursor.
+// It returns the decoded Cursor and an error if the cursor is malformed.
+func DecodeCursor(cursor string) (Cursor, error) {
+	var decoded Cursor
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	err = json.Unmarshal(data, &decoded)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	return decoded, nil
+}
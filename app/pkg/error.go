@@ -2,13 +2,17 @@ package pkg
 
 import (
 	"event-booking-api/app/constant"
+	"event-booking-api/app/domain/dto"
 	"fmt"
+
+	"github.com/gin-gonic/gin"
 )
 
 type CustomError struct {
-	Type constant.ResponseStatus
-	Msg  string
-	Err  error
+	Type    constant.ResponseStatus
+	Msg     string
+	Err     error
+	Details []dto.FieldError
 }
 
 func (e *CustomError) Error() string {
@@ -18,10 +22,18 @@ func (e *CustomError) Error() string {
 	return fmt.Sprint(e.Msg)
 }
 
+func (e *CustomError) Unwrap() error {
+	return e.Err
+}
+
 func NewCustomError(typ constant.ResponseStatus, msg string, err error) *CustomError {
 	return &CustomError{Type: typ, Msg: msg, Err: err}
 }
 
+func NewInvalidRequestError(msg string, err error) *CustomError {
+	return NewCustomError(constant.InvalidRequest, msg, err)
+}
+
 func NewNotFoundError(msg string, err error) *CustomError {
 	return NewCustomError(constant.DataNotFound, msg, err)
 }
@@ -33,3 +45,13 @@ func NewConflictError(msg string, err error) *CustomError {
 func NewUnauthorizedError(msg string, err error) *CustomError {
 	return NewCustomError(constant.Unauthorized, msg, err)
 }
+
+func NewTooManyRequestsError(msg string, err error) *CustomError {
+	return NewCustomError(constant.TooManyRequests, msg, err)
+}
+
+// AbortWithError records err for the ErrorHandler middleware and stops the remaining handlers.
+func AbortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,86 @@
+package pkg
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+const (
+	highlightPreTag  = "<mark>"
+	highlightPostTag = "</mark>"
+)
+
+// SearchTerms splits a search query into its distinct, lower-cased terms.
+func SearchTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// Highlight wraps every occurrence of the terms in text with <mark> tags.
+// The text is HTML escaped, so that the snippet is safe to render as HTML.
+// Text longer than maxLen runes is cut to a snippet around the first match.
+// It returns an empty string if none of the terms occur in text.
+func Highlight(text string, terms []string, maxLen int) string {
+	if len(terms) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	match := pattern.FindStringIndex(text)
+	if match == nil {
+		return ""
+	}
+
+	runes := []rune(text)
+	prefix, suffix := "", ""
+	if len(runes) > maxLen {
+		start := len([]rune(text[:match[0]])) - maxLen/4
+		if start < 0 {
+			start = 0
+		}
+		end := start + maxLen
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxLen
+		}
+
+		if start > 0 {
+			prefix = "..."
+		}
+		if end < len(runes) {
+			suffix = "..."
+		}
+		text = string(runes[start:end])
+	}
+
+	var snippet strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		snippet.WriteString(html.EscapeString(text[last:match[0]]))
+		snippet.WriteString(highlightPreTag + html.EscapeString(text[match[0]:match[1]]) + highlightPostTag)
+		last = match[1]
+	}
+	snippet.WriteString(html.EscapeString(text[last:]))
+
+	return prefix + snippet.String() + suffix
+}
+
+// EscapeLike escapes the LIKE wildcard characters in value.
+func EscapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -0,0 +1,26 @@
+package pkg
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying logger as its request-scoped logger.
+func ContextWithLogger(ctx context.Context, logger *log.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger returns the request-scoped logger of ctx, which carries the request ID and, once the request
+// is authenticated, the user ID. Outside of a request it falls back to the standard logger.
+// The logger is bound to ctx, so that hooks can add the trace and span IDs.
+func Logger(ctx context.Context) *log.Entry {
+	logger, ok := ctx.Value(loggerKey{}).(*log.Entry)
+	if !ok {
+		return log.WithContext(ctx)
+	}
+
+	return logger.WithContext(ctx)
+}
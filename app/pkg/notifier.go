@@ -0,0 +1,61 @@
+package pkg
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Message is a notification addressed to a user.
+type Message struct {
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// Notifier delivers messages to users, e.g. by email.
+type Notifier interface {
+	Send(message Message) error
+}
+
+// LogNotifier writes messages to the application log instead of delivering them. Intended for local development.
+type LogNotifier struct{}
+
+func (n LogNotifier) Send(message Message) error {
+	log.WithFields(log.Fields{"to": message.To, "subject": message.Subject}).Info("Notification: ", message.Body)
+	return nil
+}
+
+// FileNotifier appends messages as JSON lines to a file. Intended for local development and tests.
+type FileNotifier struct {
+	path string
+	mu   *sync.Mutex
+}
+
+func (n FileNotifier) Send(message Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	message.SentAt = time.Now()
+	return json.NewEncoder(file).Encode(message)
+}
+
+// NotifierInit selects the notifier of the configuration ("log" or "file").
+// The file notifier writes to the configured file.
+func NotifierInit(cfg NotifierConfig) Notifier {
+	if cfg.Type == "file" {
+		return FileNotifier{path: cfg.File, mu: &sync.Mutex{}}
+	}
+
+	return LogNotifier{}
+}
@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimitSweepInterval is how often the in-memory store forgets the buckets of idle clients.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimitStore keeps the token buckets of the rate limiter.
+// The in-memory store only limits the requests to a single instance; instances behind a load balancer
+// need an implementation backed by a shared store, e.g. Redis.
+type RateLimitStore interface {
+	// Take removes a token from the bucket identified by key, which holds up to limit.Requests tokens
+	// and is refilled at limit.Requests per limit.Period.
+	// It returns whether a token was available and, if not, how long until one is.
+	Take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error)
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+// MemoryRateLimitStore keeps the token buckets in memory.
+type MemoryRateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+}
+
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	refillRate := capacity / limit.Period.Seconds()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, updatedAt: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = min(capacity, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*refillRate)
+	bucket.updatedAt = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+	bucket.fullAt = now.Add(time.Duration((capacity - bucket.tokens) / refillRate * float64(time.Second)))
+
+	if allowed {
+		return true, 0, nil
+	}
+
+	return false, time.Duration((1 - bucket.tokens) / refillRate * float64(time.Second)), nil
+}
+
+// sweep forgets the buckets that have refilled completely, as they are equivalent to new ones.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < rateLimitSweepInterval {
+		return
+	}
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.sweptAt = now
+}
+
+// RateLimitStoreInit creates the in-memory rate limit store.
+func RateLimitStoreInit() RateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		sweptAt: time.Now(),
+	}
+}
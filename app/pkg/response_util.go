@@ -20,3 +20,9 @@ func BuildResponse_[T any](status string, message string, data T) dto.ApiRespons
 		Data:            data,
 	}
 }
+
+func BuildPageResponse[T any](responseStatus constant.ResponseStatus, data T, pagination dto.Pagination) dto.ApiResponse[T] {
+	response := BuildResponse(responseStatus, data)
+	response.Pagination = &pagination
+	return response
+}
@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// secretBoxKeySize is the size of the AES-256 key of a SecretBox.
+const secretBoxKeySize = 32
+
+// SecretBox encrypts secrets that must be stored at rest but read back in plain, such as TOTP secrets,
+// with AES-256-GCM.
+type SecretBox struct {
+	aead cipher.AEAD
+}
+
+// NewSecretBox returns a SecretBox using the base64-encoded 32-byte key.
+// It returns an error if the key is malformed.
+func NewSecretBox(key string) (*SecretBox, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("key is not base64: %w", err)
+	}
+
+	if len(raw) != secretBoxKeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, got %d", secretBoxKeySize, len(raw))
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretBox{aead: aead}, nil
+}
+
+// Seal encrypts the secret under a random nonce.
+// It returns the base64-encoded nonce and ciphertext and an error, if any.
+func (b *SecretBox) Seal(secret string) (string, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b.aead.Seal(nonce, nonce, []byte(secret), nil)), nil
+}
+
+// Open decrypts a secret encrypted by Seal.
+// It returns the secret and an error if the value was not sealed with the key of the SecretBox.
+func (b *SecretBox) Open(sealed string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < b.aead.NonceSize() {
+		return "", errors.New("sealed secret too short")
+	}
+
+	nonce, ciphertext := raw[:b.aead.NonceSize()], raw[b.aead.NonceSize():]
+	secret, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+// SecretBoxInit returns the SecretBox for the MFA encryption key. The key is only required by the server,
+// so it is checked here rather than when the configuration is loaded.
+func SecretBoxInit(cfg MFAConfig) *SecretBox {
+	if cfg.EncryptionKey == "" {
+		log.Fatal("MFA_ENCRYPTION_KEY is required")
+	}
+
+	box, err := NewSecretBox(cfg.EncryptionKey)
+	if err != nil {
+		log.Fatal("Error loading MFA_ENCRYPTION_KEY: ", err)
+	}
+
+	return box
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"errors"
+	"event-booking-api/app/domain/dto"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+)
+
+// Validator returns the shared validator instance, configured on first use.
+// Field errors are reported by their json or form tag name and the custom rules below are registered:
+//   - future: the time.Time field lies in the future.
+func Validator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validator.New(validator.WithRequiredStructEnabled())
+
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, key := range []string{"json", "form"} {
+				name := strings.SplitN(field.Tag.Get(key), ",", 2)[0]
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+
+		_ = validate.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+			t, ok := fl.Field().Interface().(time.Time)
+			return ok && t.After(time.Now())
+		})
+	})
+
+	return validate
+}
+
+// ValidateStruct validates the struct, skipping the given fields.
+// It returns an invalid request error listing every failed field, or nil if the struct is valid.
+func ValidateStruct(s interface{}, except ...string) error {
+	if len(except) > 0 {
+		return NewValidationError(Validator().StructExcept(s, except...))
+	}
+
+	return NewValidationError(Validator().Struct(s))
+}
+
+// ValidatePartial validates only the fields of the struct that are set, as used for partial updates.
+// It returns an invalid request error listing every failed field, or nil if the set fields are valid.
+func ValidatePartial(s interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(s))
+
+	var fields []string
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.IsExported() && !field.Anonymous && !v.Field(i).IsZero() {
+			fields = append(fields, field.Name)
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return NewValidationError(Validator().StructPartial(s, fields...))
+}
+
+// NewValidationError converts an error returned by the validator into an invalid request error
+// whose details hold one dto.FieldError per failed field. It returns nil if err is nil.
+func NewValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return NewInvalidRequestError("Invalid request data", err)
+	}
+
+	details := make([]dto.FieldError, len(validationErrs))
+	for i, fieldErr := range validationErrs {
+		details[i] = dto.FieldError{
+			Field:   fieldPath(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Message: fieldErrorMessage(fieldErr),
+		}
+	}
+
+	customErr := NewInvalidRequestError("Validation failed", err)
+	customErr.Details = details
+	return customErr
+}
+
+// fieldPath returns the field namespace without the leading struct name, e.g. "event_time".
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return namespace
+}
+
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	field := fieldPath(fieldErr)
+	unit := ""
+	if fieldErr.Kind() == reflect.String {
+		unit = " characters"
+	}
+
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "max":
+		if unit != "" {
+			return fmt.Sprintf("%s must be at most %s%s long", field, fieldErr.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at most %s", field, fieldErr.Param())
+	case "min":
+		if unit != "" {
+			return fmt.Sprintf("%s must be at least %s%s long", field, fieldErr.Param(), unit)
+		}
+		return fmt.Sprintf("%s must be at least %s", field, fieldErr.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	case "future":
+		return fmt.Sprintf("%s must be in the future", field)
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fieldErr.Tag())
+	}
+}
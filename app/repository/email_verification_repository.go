@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EmailVerificationRepository interface {
+	Save(ctx context.Context, request *dao.EmailVerification) error
+	Consume(ctx context.Context, tokenHash string) (dao.EmailVerification, error)
+	CountSince(ctx context.Context, userId int, since time.Time) (int64, error)
+	InvalidateByUserId(ctx context.Context, userId int) error
+}
+
+type EmailVerificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores a new email verification token to the database.
+// It returns an error, if any.
+func (e EmailVerificationRepositoryImpl) Save(ctx context.Context, request *dao.EmailVerification) error {
+	err := e.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving email verification: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume marks the unused, unexpired email verification token with the given hash as used,
+// together with every other outstanding token of the same user, and marks the user's email as verified.
+// It returns the consumed dao.EmailVerification and an error if the token is invalid or the operation fails.
+func (e EmailVerificationRepositoryImpl) Consume(ctx context.Context, tokenHash string) (dao.EmailVerification, error) {
+	var verification dao.EmailVerification
+
+	err := e.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&verification).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return pkg.NewUnauthorizedError("Invalid or expired verification token", err)
+			}
+
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&dao.EmailVerification{}).
+			Where("user_id = ? AND used_at IS NULL", verification.UserID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return pkg.NewUnauthorizedError("Invalid or expired verification token", nil)
+		}
+
+		return tx.Model(&dao.User{}).
+			Where("id = ? AND email_verified_at IS NULL", verification.UserID).
+			Update("email_verified_at", now).Error
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error consuming email verification: ", err)
+			return dao.EmailVerification{}, err
+		}
+
+		pkg.Logger(ctx).Error("Error consuming email verification: ", err)
+		return dao.EmailVerification{}, err
+	}
+
+	return verification, nil
+}
+
+// CountSince counts the email verification tokens issued to the user since the given time.
+// It returns the count and an error, if any.
+func (e EmailVerificationRepositoryImpl) CountSince(ctx context.Context, userId int, since time.Time) (int64, error) {
+	var count int64
+
+	err := e.db.WithContext(ctx).Model(&dao.EmailVerification{}).
+		Where("user_id = ? AND created_at > ?", userId, since).
+		Count(&count).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error counting email verifications: ", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// InvalidateByUserId marks every outstanding email verification token of the given user as used,
+// so that tokens sent to a previous email address can no longer verify the user.
+// It returns an error if the operation fails.
+func (e EmailVerificationRepositoryImpl) InvalidateByUserId(ctx context.Context, userId int) error {
+	err := e.db.WithContext(ctx).Model(&dao.EmailVerification{}).
+		Where("user_id = ? AND used_at IS NULL", userId).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error invalidating email verifications by user id: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func EmailVerificationRepositoryInit(db *gorm.DB) *EmailVerificationRepositoryImpl {
+	return &EmailVerificationRepositoryImpl{
+		db: db,
+	}
+}
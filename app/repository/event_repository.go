@@ -1,65 +1,256 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/domain/dto"
 	"event-booking-api/app/pkg"
+	"fmt"
+	"strings"
+	"time"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type EventRepository interface {
-	Save(request *dao.Event) (dao.Event, error)
-	FindAllEvent() ([]dao.Event, error)
-	FindEventById(id int) (dao.Event, error)
-	DeleteEventById(id int) error
+	Save(ctx context.Context, request *dao.Event) (dao.Event, error)
+	FindAllEvent(ctx context.Context, query dto.EventQuery) ([]dao.Event, dto.Pagination, error)
+	SearchEvent(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error)
+	FindEventById(ctx context.Context, id int) (dao.Event, error)
+	FindEventByIdForUpdate(ctx context.Context, id int) (dao.Event, error)
+	DeleteEventById(ctx context.Context, id int) error
 }
 
 type EventRepositoryImpl struct {
-	db *gorm.DB
+	db       *gorm.DB
+	fullText bool
 }
 
+// mysqlErrNoFullTextIndex is returned by MySQL when no FULLTEXT index matches the searched columns.
+const mysqlErrNoFullTextIndex = 1191
+
 // Save stores the event to the database.
 // It returns the saved dao.Event and an error, if any.
-func (e EventRepositoryImpl) Save(request *dao.Event) (dao.Event, error) {
-	err := e.db.Save(request).Error
+func (e EventRepositoryImpl) Save(ctx context.Context, request *dao.Event) (dao.Event, error) {
+	err := e.db.WithContext(ctx).Save(request).Error
 	if err != nil {
-		log.Error("Error saving event: ", err)
+		pkg.Logger(ctx).Error("Error saving event: ", err)
 		return dao.Event{}, err
 	}
 
 	return *request, nil
 }
 
-// FindAllEvent retrieves all events from the database.
-// It returns a slice of dao.Event and an error, if any.
-func (e EventRepositoryImpl) FindAllEvent() ([]dao.Event, error) {
+// FindAllEvent retrieves a page of events matching the query filters from the database.
+// Pages are addressed either by page number or, when a cursor is given, by the position after the cursor.
+// It returns a slice of dao.Event, the dto.Pagination of the page and an error, if any.
+func (e EventRepositoryImpl) FindAllEvent(ctx context.Context, query dto.EventQuery) ([]dao.Event, dto.Pagination, error) {
 	var events []dao.Event
+	var total int64
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		if query.Location != "" {
+			db = db.Where("location LIKE ?", "%"+pkg.EscapeLike(query.Location)+"%")
+		}
+		if query.UserID != 0 {
+			db = db.Where("user_id = ?", query.UserID)
+		}
+		if !query.From.IsZero() {
+			db = db.Where("event_time >= ?", query.From)
+		}
+		if !query.To.IsZero() {
+			db = db.Where("event_time <= ?", query.To)
+		}
+		if query.Upcoming {
+			db = db.Where("event_time >= ?", time.Now())
+		}
+		return db
+	}
+
+	err := e.db.WithContext(ctx).Model(&dao.Event{}).Scopes(filter).Count(&total).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error counting all events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	direction, operator := "ASC", ">"
+	if query.Order == "desc" {
+		direction, operator = "DESC", "<"
+	}
+
+	db := e.db.WithContext(ctx).Select("id, name, description, location, event_time, capacity, user_id").Scopes(filter)
+	pagination := dto.Pagination{Limit: query.Limit, Total: total}
+
+	if query.Cursor != "" {
+		cursor, err := pkg.DecodeCursor(query.Cursor)
+		if err != nil {
+			pkg.Logger(ctx).Info("Error decoding event cursor: ", err)
+			return nil, dto.Pagination{}, pkg.NewInvalidRequestError("Invalid cursor", err)
+		}
+
+		if cursor.Sort != query.Sort || cursor.Order != query.Order {
+			pkg.Logger(ctx).Info("Event cursor of sort ", cursor.Sort, " ", cursor.Order, " used with sort ", query.Sort, " ", query.Order)
+			return nil, dto.Pagination{}, pkg.NewInvalidRequestError("Cursor does not match the sort and order", nil)
+		}
 
-	err := e.db.Select("id, name, description, location, event_time, user_id").
+		var value interface{} = cursor.Value
+		if query.Sort == "event_time" {
+			value, err = time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				pkg.Logger(ctx).Info("Error decoding event cursor: ", err)
+				return nil, dto.Pagination{}, pkg.NewInvalidRequestError("Invalid cursor", err)
+			}
+		}
+
+		db = db.Where(fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", query.Sort, operator), value, value, cursor.ID)
+	} else {
+		pagination.Page = query.Page
+		db = db.Offset((query.Page - 1) * query.Limit)
+	}
+
+	err = db.Order(fmt.Sprintf("%s %s, id %s", query.Sort, direction, direction)).
+		Limit(query.Limit + 1).
 		Find(&events).Error
 	if err != nil {
-		log.Error("Error finding all events: ", err)
-		return nil, err
+		pkg.Logger(ctx).Error("Error finding all events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+
+		last := events[len(events)-1]
+		if query.Sort == "event_time" {
+			pagination.NextCursor = pkg.EncodeCursor(query.Sort, query.Order, last.EventTime.Format(time.RFC3339Nano), last.ID)
+		} else {
+			pagination.NextCursor = pkg.EncodeCursor(query.Sort, query.Order, last.Name, last.ID)
+		}
+	}
+
+	return events, pagination, nil
+}
+
+// SearchEvent retrieves a page of events matching the search terms in their name, description or location.
+// It uses the MySQL FULLTEXT index and falls back to LIKE matching when full-text search is unavailable.
+// Results are ordered by relevance.
+// It returns a slice of dao.EventSearchResult, the dto.Pagination of the page and an error, if any.
+func (e EventRepositoryImpl) SearchEvent(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error) {
+	if e.fullText {
+		results, pagination, err := e.searchEventFullText(ctx, query)
+		var mysqlErr *mysql.MySQLError
+		if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlErrNoFullTextIndex {
+			return results, pagination, err
+		}
+
+		pkg.Logger(ctx).Warn("Full-text index unavailable, falling back to LIKE search: ", err)
 	}
 
-	return events, nil
+	return e.searchEventLike(ctx, query)
+}
+
+func (e EventRepositoryImpl) searchEventFullText(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error) {
+	var results []dao.EventSearchResult
+	var total int64
+
+	match := "MATCH(name, description, location) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+	err := e.db.WithContext(ctx).Model(&dao.Event{}).Where(match, query.Q).Count(&total).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error counting searched events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	err = e.db.WithContext(ctx).Model(&dao.Event{}).
+		Select("id, name, description, location, event_time, capacity, user_id, "+match+" AS score", query.Q).
+		Where(match, query.Q).
+		Order("score DESC, id").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&results).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error searching events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	return results, dto.Pagination{Page: query.Page, Limit: query.Limit, Total: total}, nil
+}
+
+func (e EventRepositoryImpl) searchEventLike(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error) {
+	var results []dao.EventSearchResult
+	var total int64
+
+	var conditions, scores []string
+	var conditionArgs, scoreArgs []interface{}
+	for _, term := range pkg.SearchTerms(query.Q) {
+		pattern := "%" + pkg.EscapeLike(term) + "%"
+
+		conditions = append(conditions, "name LIKE ? OR description LIKE ? OR location LIKE ?")
+		conditionArgs = append(conditionArgs, pattern, pattern, pattern)
+
+		scores = append(scores, "(CASE WHEN name LIKE ? THEN 3 ELSE 0 END) + "+
+			"(CASE WHEN location LIKE ? THEN 2 ELSE 0 END) + "+
+			"(CASE WHEN description LIKE ? THEN 1 ELSE 0 END)")
+		scoreArgs = append(scoreArgs, pattern, pattern, pattern)
+	}
+	condition := strings.Join(conditions, " OR ")
+
+	err := e.db.WithContext(ctx).Model(&dao.Event{}).Where(condition, conditionArgs...).Count(&total).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error counting searched events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	err = e.db.WithContext(ctx).Model(&dao.Event{}).
+		Select("id, name, description, location, event_time, capacity, user_id, "+strings.Join(scores, " + ")+" AS score", scoreArgs...).
+		Where(condition, conditionArgs...).
+		Order("score DESC, id").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&results).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error searching events: ", err)
+		return nil, dto.Pagination{}, err
+	}
+
+	return results, dto.Pagination{Page: query.Page, Limit: query.Limit, Total: total}, nil
 }
 
 // FindEventById retrieves a event by the given ID from the database.
 // It returns the dao.Event and an error, if any.
-func (e EventRepositoryImpl) FindEventById(id int) (dao.Event, error) {
+func (e EventRepositoryImpl) FindEventById(ctx context.Context, id int) (dao.Event, error) {
 	event := dao.Event{ID: id}
 
-	err := e.db.First(&event).Error
+	err := e.db.WithContext(ctx).First(&event).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Info("Error finding event by id: ", err)
+			pkg.Logger(ctx).Info("Error finding event by id: ", err)
 			return dao.Event{}, pkg.NewNotFoundError("Event not found", err)
 		}
 
-		log.Error("Error finding event by id: ", err)
+		pkg.Logger(ctx).Error("Error finding event by id: ", err)
+		return dao.Event{}, err
+	}
+
+	return event, nil
+}
+
+// FindEventByIdForUpdate retrieves a event by the given ID from the database and locks it until the end of the
+// transaction, so that concurrent updates of the event are applied one after the other.
+// It returns the dao.Event and an error, if any.
+func (e EventRepositoryImpl) FindEventByIdForUpdate(ctx context.Context, id int) (dao.Event, error) {
+	event := dao.Event{ID: id}
+
+	err := e.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&event).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding event by id: ", err)
+			return dao.Event{}, pkg.NewNotFoundError("Event not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding event by id: ", err)
 		return dao.Event{}, err
 	}
 
@@ -68,10 +259,10 @@ func (e EventRepositoryImpl) FindEventById(id int) (dao.Event, error) {
 
 // DeleteEventById deletes the event by the given ID from the database.
 // It returns an error if the deletion fails.
-func (e EventRepositoryImpl) DeleteEventById(id int) error {
-	err := e.db.Delete(&dao.Event{}, id).Error
+func (e EventRepositoryImpl) DeleteEventById(ctx context.Context, id int) error {
+	err := e.db.WithContext(ctx).Delete(&dao.Event{}, id).Error
 	if err != nil {
-		log.Error("Error deleting event: ", err)
+		pkg.Logger(ctx).Error("Error deleting event: ", err)
 		return err
 	}
 
@@ -79,11 +270,8 @@ func (e EventRepositoryImpl) DeleteEventById(id int) error {
 }
 
 func EventRepositoryInit(db *gorm.DB) *EventRepositoryImpl {
-	if err := db.AutoMigrate(&dao.Event{}); err != nil {
-		log.Fatal("Error AutoMigrating Event: ", err)
-	}
-
 	return &EventRepositoryImpl{
-		db: db,
+		db:       db,
+		fullText: db.Dialector.Name() == "mysql",
 	}
 }
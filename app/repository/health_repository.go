@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"event-booking-api/app/migration"
+	"event-booking-api/app/pkg"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	FindMigrationStatus(ctx context.Context) ([]migration.Status, error)
+}
+
+type HealthRepositoryImpl struct {
+	db       *gorm.DB
+	migrator *migration.Migrator
+}
+
+// Ping verifies that a connection to the database can be established.
+// It returns an error if the database is unreachable.
+func (h HealthRepositoryImpl) Ping(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+
+	err = sqlDB.PingContext(ctx)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error pinging database: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindMigrationStatus retrieves the status of every known schema migration from the database.
+// It returns a slice of migration.Status and an error, if any.
+func (h HealthRepositoryImpl) FindMigrationStatus(ctx context.Context) ([]migration.Status, error) {
+	statuses, err := h.migrator.Status(ctx)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error finding migration status: ", err)
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+func HealthRepositoryInit(db *gorm.DB) *HealthRepositoryImpl {
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatal("Error getting database connection: ", err)
+	}
+
+	migrator, err := migration.NewMigrator(sqlDB)
+	if err != nil {
+		log.Fatal("Error loading migrations: ", err)
+	}
+
+	return &HealthRepositoryImpl{
+		db:       db,
+		migrator: migrator,
+	}
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository interface {
+	Save(ctx context.Context, request *dao.LoginAttempt) error
+	FindRecentByUserId(ctx context.Context, userId, limit int) ([]dao.LoginAttempt, error)
+}
+
+type LoginAttemptRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores a login attempt to the database.
+// It returns an error, if any.
+func (l LoginAttemptRepositoryImpl) Save(ctx context.Context, request *dao.LoginAttempt) error {
+	err := l.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving login attempt: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindRecentByUserId retrieves the most recent login attempts of the user with the given ID, newest first.
+// It returns at most limit dao.LoginAttempt and an error, if any.
+func (l LoginAttemptRepositoryImpl) FindRecentByUserId(ctx context.Context, userId, limit int) ([]dao.LoginAttempt, error) {
+	var attempts []dao.LoginAttempt
+
+	err := l.db.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&attempts).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error finding login attempts: ", err)
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+func LoginAttemptRepositoryInit(db *gorm.DB) *LoginAttemptRepositoryImpl {
+	return &LoginAttemptRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MfaChallengeRepository interface {
+	Save(ctx context.Context, request *dao.MfaChallenge) error
+	FindMfaChallengeByHash(ctx context.Context, tokenHash string) (dao.MfaChallenge, error)
+	Use(ctx context.Context, id int) error
+}
+
+type MfaChallengeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores a new MFA challenge to the database.
+// It returns an error, if any.
+func (m MfaChallengeRepositoryImpl) Save(ctx context.Context, request *dao.MfaChallenge) error {
+	err := m.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving MFA challenge: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindMfaChallengeByHash retrieves the unused, unexpired MFA challenge with the given token hash from the database.
+// It returns the dao.MfaChallenge and an error if the token is invalid or the operation fails.
+func (m MfaChallengeRepositoryImpl) FindMfaChallengeByHash(ctx context.Context, tokenHash string) (dao.MfaChallenge, error) {
+	var challenge dao.MfaChallenge
+
+	err := m.db.WithContext(ctx).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+		First(&challenge).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding MFA challenge: ", err)
+			return dao.MfaChallenge{}, pkg.NewUnauthorizedError("Invalid or expired MFA token", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding MFA challenge: ", err)
+		return dao.MfaChallenge{}, err
+	}
+
+	return challenge, nil
+}
+
+// Use marks the MFA challenge with the given ID as used, so that it cannot complete another login.
+// It returns an error if the challenge was already used or the operation fails.
+func (m MfaChallengeRepositoryImpl) Use(ctx context.Context, id int) error {
+	result := m.db.WithContext(ctx).Model(&dao.MfaChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		pkg.Logger(ctx).Error("Error using MFA challenge: ", result.Error)
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		pkg.Logger(ctx).Info("MFA challenge already used: ", id)
+		return pkg.NewUnauthorizedError("Invalid or expired MFA token", nil)
+	}
+
+	return nil
+}
+
+func MfaChallengeRepositoryInit(db *gorm.DB) *MfaChallengeRepositoryImpl {
+	return &MfaChallengeRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OidcStateRepository interface {
+	Save(ctx context.Context, request *dao.OidcState) error
+	Consume(ctx context.Context, stateHash string) (dao.OidcState, error)
+}
+
+type OidcStateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores the state of a new OpenID Connect authorization request to the database.
+// It returns an error, if any.
+func (o OidcStateRepositoryImpl) Save(ctx context.Context, request *dao.OidcState) error {
+	err := o.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving OIDC state: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume marks the unused, unexpired authorization request state with the given hash as used.
+// The state is only consumed once even if several callbacks race with it.
+// It returns the consumed dao.OidcState and an error if the state is invalid or the operation fails.
+func (o OidcStateRepositoryImpl) Consume(ctx context.Context, stateHash string) (dao.OidcState, error) {
+	var state dao.OidcState
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("state_hash = ? AND used_at IS NULL AND expires_at > ?", stateHash, time.Now()).
+			First(&state).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return pkg.NewUnauthorizedError("Invalid or expired login state", err)
+			}
+
+			return err
+		}
+
+		result := tx.Model(&dao.OidcState{}).
+			Where("id = ? AND used_at IS NULL", state.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return pkg.NewUnauthorizedError("Invalid or expired login state", nil)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error consuming OIDC state: ", err)
+			return dao.OidcState{}, err
+		}
+
+		pkg.Logger(ctx).Error("Error consuming OIDC state: ", err)
+		return dao.OidcState{}, err
+	}
+
+	return state, nil
+}
+
+func OidcStateRepositoryInit(db *gorm.DB) *OidcStateRepositoryImpl {
+	return &OidcStateRepositoryImpl{
+		db: db,
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Save(ctx context.Context, request *dao.PasswordReset) error
+	Consume(ctx context.Context, tokenHash string) (dao.PasswordReset, error)
+}
+
+type PasswordResetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores a new password reset token to the database.
+// It returns an error, if any.
+func (p PasswordResetRepositoryImpl) Save(ctx context.Context, request *dao.PasswordReset) error {
+	err := p.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving password reset: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// Consume marks the unused, unexpired password reset token with the given hash as used,
+// together with every other outstanding token of the same user.
+// The token is only consumed once even if several requests race with it.
+// It returns the consumed dao.PasswordReset and an error if the token is invalid or the operation fails.
+func (p PasswordResetRepositoryImpl) Consume(ctx context.Context, tokenHash string) (dao.PasswordReset, error) {
+	var reset dao.PasswordReset
+
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).
+			First(&reset).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return pkg.NewUnauthorizedError("Invalid or expired reset token", err)
+			}
+
+			return err
+		}
+
+		result := tx.Model(&dao.PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return pkg.NewUnauthorizedError("Invalid or expired reset token", nil)
+		}
+
+		return tx.Model(&dao.PasswordReset{}).
+			Where("user_id = ? AND used_at IS NULL", reset.UserID).
+			Update("used_at", time.Now()).Error
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error consuming password reset: ", err)
+			return dao.PasswordReset{}, err
+		}
+
+		pkg.Logger(ctx).Error("Error consuming password reset: ", err)
+		return dao.PasswordReset{}, err
+	}
+
+	return reset, nil
+}
+
+func PasswordResetRepositoryInit(db *gorm.DB) *PasswordResetRepositoryImpl {
+	return &PasswordResetRepositoryImpl{
+		db: db,
+	}
+}
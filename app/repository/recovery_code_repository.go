@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository interface {
+	ReplaceByUserId(ctx context.Context, userId int, codes []dao.RecoveryCode) error
+	Use(ctx context.Context, userId int, codeHash string) (bool, error)
+	DeleteByUserId(ctx context.Context, userId int) error
+}
+
+type RecoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// ReplaceByUserId deletes the recovery codes of the user with the given ID and stores the given codes instead.
+// It returns an error, if any.
+func (r RecoveryCodeRepositoryImpl) ReplaceByUserId(ctx context.Context, userId int, codes []dao.RecoveryCode) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userId).Delete(&dao.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		pkg.Logger(ctx).Error("Error replacing recovery codes: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// Use marks the unused recovery code with the given hash of the user with the given ID as used.
+// It returns true if such a code existed and an error, if any.
+func (r RecoveryCodeRepositoryImpl) Use(ctx context.Context, userId int, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&dao.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Limit(1).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		pkg.Logger(ctx).Error("Error using recovery code: ", result.Error)
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteByUserId deletes the recovery codes of the user with the given ID from the database.
+// It returns an error if the deletion fails.
+func (r RecoveryCodeRepositoryImpl) DeleteByUserId(ctx context.Context, userId int) error {
+	err := r.db.WithContext(ctx).Where("user_id = ?", userId).Delete(&dao.RecoveryCode{}).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error deleting recovery codes: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func RecoveryCodeRepositoryInit(db *gorm.DB) *RecoveryCodeRepositoryImpl {
+	return &RecoveryCodeRepositoryImpl{
+		db: db,
+	}
+}
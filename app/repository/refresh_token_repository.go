@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	Save(ctx context.Context, request *dao.RefreshToken) error
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (dao.RefreshToken, error)
+	Rotate(ctx context.Context, current dao.RefreshToken, next *dao.RefreshToken) error
+	RevokeFamily(ctx context.Context, familyId string) error
+	RevokeAllByUserId(ctx context.Context, userId int) error
+	IsFamilyActive(ctx context.Context, familyId string) (bool, error)
+}
+
+type RefreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save stores a new refresh token to the database.
+// It returns an error, if any.
+func (r RefreshTokenRepositoryImpl) Save(ctx context.Context, request *dao.RefreshToken) error {
+	err := r.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error saving refresh token: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindRefreshTokenByHash retrieves the refresh token with the given hash from the database.
+// It returns the dao.RefreshToken and an error, if any.
+func (r RefreshTokenRepositoryImpl) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (dao.RefreshToken, error) {
+	var token dao.RefreshToken
+
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding refresh token by hash: ", err)
+			return dao.RefreshToken{}, pkg.NewUnauthorizedError("Invalid refresh token", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding refresh token by hash: ", err)
+		return dao.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// Rotate marks the current refresh token as used and stores the next token of the same family.
+// The current token is only consumed if it is still unused and unrevoked, so that two requests
+// racing with the same token cannot both obtain a successor.
+// It returns an error, if any.
+func (r RefreshTokenRepositoryImpl) Rotate(ctx context.Context, current dao.RefreshToken, next *dao.RefreshToken) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&dao.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return pkg.NewUnauthorizedError("Refresh token already used", nil)
+		}
+
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error rotating refresh token: ", err)
+			return err
+		}
+
+		pkg.Logger(ctx).Error("Error rotating refresh token: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every refresh token of the given family, ending the session it belongs to.
+// It returns an error if the revocation fails.
+func (r RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyId string) error {
+	err := r.db.WithContext(ctx).Model(&dao.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error revoking refresh token family: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// RevokeAllByUserId revokes every refresh token of the given user, ending all of their sessions.
+// It returns an error if the revocation fails.
+func (r RefreshTokenRepositoryImpl) RevokeAllByUserId(ctx context.Context, userId int) error {
+	err := r.db.WithContext(ctx).Model(&dao.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error revoking refresh tokens by user id: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// IsFamilyActive reports whether the given family still holds an unrevoked, unexpired refresh token.
+// It returns the result and an error, if any.
+func (r RefreshTokenRepositoryImpl) IsFamilyActive(ctx context.Context, familyId string) (bool, error) {
+	var active int64
+
+	err := r.db.WithContext(ctx).Model(&dao.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > ?", familyId, time.Now()).
+		Count(&active).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error checking refresh token family: ", err)
+		return false, err
+	}
+
+	return active > 0, nil
+}
+
+func RefreshTokenRepositoryInit(db *gorm.DB) *RefreshTokenRepositoryImpl {
+	return &RefreshTokenRepositoryImpl{
+		db: db,
+	}
+}
@@ -1,18 +1,25 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"event-booking-api/app/domain/dao"
 	"event-booking-api/app/pkg"
+	"fmt"
 
-	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RegisterRepository interface {
-	Save(request *dao.Register) error
-	Delete(eventId, userId int) error
-	FindAttendeesEmailById(eventId int) ([]string, error)
+	Save(ctx context.Context, request *dao.Register) error
+	Cancel(ctx context.Context, eventId, userId int) error
+	UpdateCapacity(ctx context.Context, eventId, capacity int) error
+	FindRegister(ctx context.Context, eventId, userId int) (dao.Register, error)
+	FindWaitlistById(ctx context.Context, eventId int) ([]dao.Register, error)
+	FindAttendeesEmailById(ctx context.Context, eventId int) ([]string, error)
+	DeleteByEventId(ctx context.Context, eventId int) error
+	DeleteByUserId(ctx context.Context, userId int) error
 }
 
 type RegisterRepositoryImpl struct {
@@ -20,57 +27,383 @@ type RegisterRepositoryImpl struct {
 }
 
 // Save stores a new user registration for an event to the database.
-// It returns an error, if any.
-func (r RegisterRepositoryImpl) Save(request *dao.Register) error {
-	err := r.db.Save(request).Error
+// The event row is locked for the duration of the transaction so that concurrent
+// registrations cannot exceed the event capacity. Once the capacity is reached the
+// registration is placed on the waitlist. A capacity of 0 means unlimited.
+// It sets the status and waitlist position on the request and returns an error, if any.
+func (r RegisterRepositoryImpl) Save(ctx context.Context, request *dao.Register) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventById(tx, request.EventID)
+		if err != nil {
+			return err
+		}
+
+		var existing int64
+		err = tx.Model(&dao.Register{}).
+			Where("event_id = ? AND user_id = ? AND status <> ?", request.EventID, request.UserID, dao.RegisterStatusCancelled).
+			Count(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if existing > 0 {
+			return pkg.NewConflictError("register record already exist", nil)
+		}
+
+		// A cancelled registration is replaced so that a returning user joins the back of the waitlist.
+		err = tx.Unscoped().Where("event_id = ? AND user_id = ?", request.EventID, request.UserID).Delete(&dao.Register{}).Error
+		if err != nil {
+			return err
+		}
+
+		request.Status = dao.RegisterStatusConfirmed
+		if event.Capacity > 0 {
+			var confirmed int64
+			err = tx.Model(&dao.Register{}).
+				Where("event_id = ? AND status = ?", request.EventID, dao.RegisterStatusConfirmed).
+				Count(&confirmed).Error
+			if err != nil {
+				return err
+			}
+
+			if confirmed >= int64(event.Capacity) {
+				request.Status = dao.RegisterStatusWaitlisted
+			}
+		}
+
+		err = tx.Create(request).Error
+		if err != nil {
+			return err
+		}
+
+		request.Position, err = findWaitlistPosition(tx, *request)
+		return err
+	})
 	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error saving register: ", err)
+			return err
+		}
+
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			log.Info("Error saving register: ", err)
+			pkg.Logger(ctx).Info("Error saving register: ", err)
 			return pkg.NewConflictError("register record already exist", err)
 		}
 
-		log.Error("Error saving register: ", err)
+		pkg.Logger(ctx).Error("Error saving register: ", err)
 		return err
 	}
 
 	return nil
 }
 
-// Delete deletes the register entry by the given event and user ID from the database.
-// It returns an error if the deletion fails.
-func (r RegisterRepositoryImpl) Delete(eventId, userId int) error {
-	err := r.db.Where("event_id = ? AND user_id = ?", eventId, userId).Delete(&dao.Register{}).Error
+// Cancel marks the register entry by the given event and user ID as cancelled.
+// Within the same transaction, the earliest waitlisted registrations are promoted
+// to fill any seats that became available.
+// It returns an error if the cancellation fails.
+func (r RegisterRepositoryImpl) Cancel(ctx context.Context, eventId, userId int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventById(tx, eventId)
+		if err != nil {
+			return err
+		}
+
+		var register dao.Register
+		err = tx.Where("event_id = ? AND user_id = ? AND status <> ?", eventId, userId, dao.RegisterStatusCancelled).
+			First(&register).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return pkg.NewNotFoundError("Register record not found", err)
+			}
+
+			return err
+		}
+
+		err = tx.Model(&register).Update("status", dao.RegisterStatusCancelled).Error
+		if err != nil {
+			return err
+		}
+
+		return promoteWaitlisted(ctx, tx, event)
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error cancelling register entry by event id and user id: ", err)
+			return err
+		}
+
+		pkg.Logger(ctx).Error("Error cancelling register entry by event id and user id: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateCapacity sets the capacity of the event by the given ID. A capacity of 0 means unlimited.
+// The event row is locked so that no registration can be confirmed between counting the confirmed
+// registrations and changing the capacity. Seats added by the new capacity go to the earliest waitlisted
+// registrations within the same transaction, before any new registration can take them.
+// It returns a conflict error if the capacity is below the confirmed registrations, or an error if the update fails.
+func (r RegisterRepositoryImpl) UpdateCapacity(ctx context.Context, eventId, capacity int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		event, err := lockEventById(tx, eventId)
+		if err != nil {
+			return err
+		}
+
+		if capacity > 0 {
+			var confirmed int64
+			err = tx.Model(&dao.Register{}).
+				Where("event_id = ? AND status = ?", eventId, dao.RegisterStatusConfirmed).
+				Count(&confirmed).Error
+			if err != nil {
+				return err
+			}
+
+			if int64(capacity) < confirmed {
+				return pkg.NewConflictError(fmt.Sprintf("Capacity below the %d confirmed registrations", confirmed), nil)
+			}
+		}
+
+		err = tx.Model(&event).Update("capacity", capacity).Error
+		if err != nil {
+			return err
+		}
+
+		event.Capacity = capacity
+		return promoteWaitlisted(ctx, tx, event)
+	})
 	if err != nil {
-		log.Error("Error deleting register entry by event id and user id: ", err)
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error updating event capacity: ", err)
+			return err
+		}
+
+		pkg.Logger(ctx).Error("Error updating event capacity: ", err)
 		return err
 	}
 
 	return nil
 }
 
-// FindAttendeesEmailByEventID retrieves the email addresses of all attendees for a given event ID.
+// FindRegister retrieves the register entry by the given event and user ID from the database.
+// The waitlist position is populated for waitlisted registrations.
+// It returns the dao.Register and an error, if any.
+func (r RegisterRepositoryImpl) FindRegister(ctx context.Context, eventId, userId int) (dao.Register, error) {
+	var register dao.Register
+
+	err := r.db.WithContext(ctx).Where("event_id = ? AND user_id = ?", eventId, userId).First(&register).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding register by event id and user id: ", err)
+			return dao.Register{}, pkg.NewNotFoundError("Register record not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding register by event id and user id: ", err)
+		return dao.Register{}, err
+	}
+
+	register.Position, err = findWaitlistPosition(r.db.WithContext(ctx), register)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error finding waitlist position: ", err)
+		return dao.Register{}, err
+	}
+
+	return register, nil
+}
+
+// FindWaitlistById retrieves the waitlisted registrations for a given event ID in promotion order.
+// It returns a slice of dao.Register and an error, if any.
+func (r RegisterRepositoryImpl) FindWaitlistById(ctx context.Context, eventId int) ([]dao.Register, error) {
+	var registers []dao.Register
+
+	err := r.db.WithContext(ctx).Where("event_id = ? AND status = ?", eventId, dao.RegisterStatusWaitlisted).
+		Order("id").
+		Find(&registers).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error finding waitlist by event id: ", err)
+		return nil, err
+	}
+
+	for i := range registers {
+		registers[i].Position = i + 1
+	}
+
+	return registers, nil
+}
+
+// FindAttendeesEmailByEventID retrieves the email addresses of all confirmed attendees for a given event ID.
 // It returns a slice of emails and an error, if any.
-func (r RegisterRepositoryImpl) FindAttendeesEmailById(eventId int) ([]string, error) {
+func (r RegisterRepositoryImpl) FindAttendeesEmailById(ctx context.Context, eventId int) ([]string, error) {
 	var emails []string
 
-	err := r.db.Model(&dao.Register{}).
+	err := r.db.WithContext(ctx).Model(&dao.Register{}).
 		Joins("JOIN users ON registers.user_id = users.id").
-		Where("registers.event_id = ?", eventId).
+		Where("registers.event_id = ? AND registers.status = ?", eventId, dao.RegisterStatusConfirmed).
 		Pluck("users.email", &emails).Error
 
 	if err != nil {
-		log.Error("Error finding attendees email by event id: ", err)
+		pkg.Logger(ctx).Error("Error finding attendees email by event id: ", err)
 		return nil, err
 	}
 
 	return emails, nil
 }
 
-func RegisterRepositoryInit(db *gorm.DB) *RegisterRepositoryImpl {
-	if err := db.AutoMigrate(&dao.Register{}); err != nil {
-		log.Fatal("Error AutoMigrating Register: ", err)
+// DeleteByEventId deletes every register entry of the event by the given ID from the database.
+// The event row is locked so that no registration can be added while the entries are deleted.
+// It returns an error if the deletion fails.
+func (r RegisterRepositoryImpl) DeleteByEventId(ctx context.Context, eventId int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := lockEventById(tx, eventId)
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("event_id = ?", eventId).Delete(&dao.Register{}).Error
+	})
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Info("Error deleting register entries by event id: ", err)
+			return err
+		}
+
+		pkg.Logger(ctx).Error("Error deleting register entries by event id: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByUserId deletes every register entry of the user by the given ID from the database.
+// The events of the user's confirmed registrations are locked, in ID order, and the seats released
+// are filled from the waitlist of each event.
+// It returns an error if the deletion fails.
+func (r RegisterRepositoryImpl) DeleteByUserId(ctx context.Context, userId int) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var eventIds []int
+		err := tx.Model(&dao.Register{}).
+			Where("user_id = ? AND status = ?", userId, dao.RegisterStatusConfirmed).
+			Order("event_id").
+			Pluck("event_id", &eventIds).Error
+		if err != nil {
+			return err
+		}
+
+		events := make([]dao.Event, 0, len(eventIds))
+		for _, eventId := range eventIds {
+			event, err := lockEventById(tx, eventId)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			events = append(events, event)
+		}
+
+		err = tx.Where("user_id = ?", userId).Delete(&dao.Register{}).Error
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			err = promoteWaitlisted(ctx, tx, event)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		pkg.Logger(ctx).Error("Error deleting register entries by user id: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// lockEventById loads the event by the given ID with a row lock held until the transaction ends.
+func lockEventById(tx *gorm.DB, eventId int) (dao.Event, error) {
+	var event dao.Event
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, capacity").
+		First(&event, eventId).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dao.Event{}, pkg.NewNotFoundError("Event not found", err)
+		}
+
+		return dao.Event{}, err
+	}
+
+	return event, nil
+}
+
+// promoteWaitlisted confirms the earliest waitlisted registrations of the locked event to fill its available seats,
+// or all of them if the event is unlimited.
+func promoteWaitlisted(ctx context.Context, tx *gorm.DB, event dao.Event) error {
+	waitlist := tx.Model(&dao.Register{}).
+		Where("event_id = ? AND status = ?", event.ID, dao.RegisterStatusWaitlisted).
+		Order("id")
+
+	if event.Capacity > 0 {
+		var confirmed int64
+		err := tx.Model(&dao.Register{}).
+			Where("event_id = ? AND status = ?", event.ID, dao.RegisterStatusConfirmed).
+			Count(&confirmed).Error
+		if err != nil {
+			return err
+		}
+
+		available := event.Capacity - int(confirmed)
+		if available <= 0 {
+			return nil
+		}
+		waitlist = waitlist.Limit(available)
+	}
+
+	var promotedIds []int
+	err := waitlist.Pluck("id", &promotedIds).Error
+	if err != nil {
+		return err
+	}
+
+	if len(promotedIds) == 0 {
+		return nil
 	}
 
+	pkg.Logger(ctx).Info("Promoting waitlisted registers: ", promotedIds)
+	return tx.Model(&dao.Register{}).
+		Where("id IN ?", promotedIds).
+		Update("status", dao.RegisterStatusConfirmed).Error
+}
+
+// findWaitlistPosition returns the 1-based waitlist position of the register, or 0 if it is not waitlisted.
+func findWaitlistPosition(tx *gorm.DB, register dao.Register) (int, error) {
+	if register.Status != dao.RegisterStatusWaitlisted {
+		return 0, nil
+	}
+
+	var position int64
+	err := tx.Model(&dao.Register{}).
+		Where("event_id = ? AND status = ? AND id <= ?", register.EventID, dao.RegisterStatusWaitlisted, register.ID).
+		Count(&position).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return int(position), nil
+}
+
+func RegisterRepositoryInit(db *gorm.DB) *RegisterRepositoryImpl {
 	return &RegisterRepositoryImpl{
 		db: db,
 	}
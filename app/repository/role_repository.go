@@ -1,29 +1,108 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
 
-	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type RoleRepository interface {
-	FindAllRole()
+	FindAllRole(ctx context.Context) ([]dao.Role, error)
+	FindRoleById(ctx context.Context, id int) (dao.Role, error)
+	FindRoleByName(ctx context.Context, name string) (dao.Role, error)
+	HasPermission(ctx context.Context, roleId int, permission string) (bool, error)
+	UpdateMfaRequired(ctx context.Context, id int, required bool) error
 }
 
 type RoleRepositoryImpl struct {
 	db *gorm.DB
 }
 
-func (r RoleRepositoryImpl) FindAllRole() {
-	panic("implement me")
+// FindAllRole retrieves all roles and their permissions from the database.
+// It returns a slice of dao.Role and an error, if any.
+func (r RoleRepositoryImpl) FindAllRole(ctx context.Context) ([]dao.Role, error) {
+	var roles []dao.Role
+
+	err := r.db.WithContext(ctx).Preload("Permissions").Find(&roles).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error finding all roles: ", err)
+		return nil, err
+	}
+
+	return roles, nil
 }
 
-func RoleRepositoryInit(db *gorm.DB) *RoleRepositoryImpl {
-	if err := db.AutoMigrate(&dao.Role{}); err != nil {
-		log.Fatal("Error AutoMigrating Role: ", err)
+// FindRoleById retrieves a role by the given ID from the database.
+// It returns the dao.Role and an error, if any.
+func (r RoleRepositoryImpl) FindRoleById(ctx context.Context, id int) (dao.Role, error) {
+	role := dao.Role{ID: id}
+
+	err := r.db.WithContext(ctx).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding role by id: ", err)
+			return dao.Role{}, pkg.NewNotFoundError("Role not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding role by id: ", err)
+		return dao.Role{}, err
+	}
+
+	return role, nil
+}
+
+// FindRoleByName retrieves a role by the given name from the database.
+// It returns the dao.Role and an error, if any.
+func (r RoleRepositoryImpl) FindRoleByName(ctx context.Context, name string) (dao.Role, error) {
+	var role dao.Role
+
+	err := r.db.WithContext(ctx).Where("role = ?", name).First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding role by name: ", err)
+			return dao.Role{}, pkg.NewNotFoundError("Role not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding role by name: ", err)
+		return dao.Role{}, err
 	}
 
+	return role, nil
+}
+
+// HasPermission checks whether the role by the given ID is granted the permission.
+// It returns true if the permission is granted and an error, if any.
+func (r RoleRepositoryImpl) HasPermission(ctx context.Context, roleId int, permission string) (bool, error) {
+	var count int64
+
+	err := r.db.WithContext(ctx).Table("role_permissions").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("role_permissions.role_id = ? AND permissions.name = ? AND permissions.deleted_at IS NULL", roleId, permission).
+		Count(&count).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error checking role permission: ", err)
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// UpdateMfaRequired sets whether users of the role by the given ID must sign in with MFA.
+// It returns an error if the update fails.
+func (r RoleRepositoryImpl) UpdateMfaRequired(ctx context.Context, id int, required bool) error {
+	err := r.db.WithContext(ctx).Model(&dao.Role{ID: id}).Update("mfa_required", required).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error updating role MFA requirement: ", err)
+		return err
+	}
+
+	return nil
+}
+
+func RoleRepositoryInit(db *gorm.DB) *RoleRepositoryImpl {
 	return &RoleRepositoryImpl{
 		db: db,
 	}
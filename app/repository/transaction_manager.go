@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories holds the repositories bound to a single transaction.
+type Repositories struct {
+	Event             EventRepository
+	Register          RegisterRepository
+	User              UserRepository
+	RefreshToken      RefreshTokenRepository
+	LoginAttempt      LoginAttemptRepository
+	MfaChallenge      MfaChallengeRepository
+	RecoveryCode      RecoveryCodeRepository
+	UserIdentity      UserIdentityRepository
+	EmailVerification EmailVerificationRepository
+}
+
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type TransactionManagerImpl struct {
+	db *gorm.DB
+}
+
+// WithinTransaction runs fn with repositories bound to a new transaction, so that the
+// operations it performs through them are committed together.
+// The transaction is rolled back if fn returns an error or panics.
+// It returns the error returned by fn or by the commit, if any.
+func (t TransactionManagerImpl) WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error {
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(Repositories{
+			Event:             &EventRepositoryImpl{db: tx, fullText: tx.Dialector.Name() == "mysql"},
+			Register:          &RegisterRepositoryImpl{db: tx},
+			User:              &UserRepositoryImpl{db: tx},
+			RefreshToken:      &RefreshTokenRepositoryImpl{db: tx},
+			LoginAttempt:      &LoginAttemptRepositoryImpl{db: tx},
+			MfaChallenge:      &MfaChallengeRepositoryImpl{db: tx},
+			RecoveryCode:      &RecoveryCodeRepositoryImpl{db: tx},
+			UserIdentity:      &UserIdentityRepositoryImpl{db: tx},
+			EmailVerification: &EmailVerificationRepositoryImpl{db: tx},
+		})
+	})
+}
+
+func TransactionManagerInit(db *gorm.DB) *TransactionManagerImpl {
+	return &TransactionManagerImpl{
+		db: db,
+	}
+}
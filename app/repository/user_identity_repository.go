@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository interface {
+	Save(ctx context.Context, request *dao.UserIdentity) error
+	FindByIssuerSubject(ctx context.Context, issuer, subject string) (dao.UserIdentity, error)
+}
+
+type UserIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// Save links the user to their account at an identity provider.
+// It returns an error if the account is already linked or the operation fails.
+func (u UserIdentityRepositoryImpl) Save(ctx context.Context, request *dao.UserIdentity) error {
+	err := u.db.WithContext(ctx).Create(request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			pkg.Logger(ctx).Info("Error saving user identity: ", err)
+			return pkg.NewConflictError("Identity already linked", err)
+		}
+
+		pkg.Logger(ctx).Error("Error saving user identity: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// FindByIssuerSubject retrieves the identity with the given subject at the identity provider by the given issuer.
+// It returns the dao.UserIdentity and an error, if any.
+func (u UserIdentityRepositoryImpl) FindByIssuerSubject(ctx context.Context, issuer, subject string) (dao.UserIdentity, error) {
+	var identity dao.UserIdentity
+
+	err := u.db.WithContext(ctx).Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding user identity: ", err)
+			return dao.UserIdentity{}, pkg.NewNotFoundError("Identity not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding user identity: ", err)
+		return dao.UserIdentity{}, err
+	}
+
+	return identity, nil
+}
+
+func UserIdentityRepositoryInit(db *gorm.DB) *UserIdentityRepositoryImpl {
+	return &UserIdentityRepositoryImpl{
+		db: db,
+	}
+}
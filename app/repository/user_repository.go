@@ -1,21 +1,26 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"event-booking-api/app/domain/dao"
 	"event-booking-api/app/pkg"
+	"time"
 
-	log "github.com/sirupsen/logrus"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository interface {
-	Save(request *dao.User) (dao.User, error)
-	FindAllUser() ([]dao.User, error)
-	FindUserById(id int) (dao.User, error)
-	DeleteUserById(id int) error
-	VerifyUser(request dao.User) (dao.User, error)
+	Save(ctx context.Context, request *dao.User) (dao.User, error)
+	FindAllUser(ctx context.Context) ([]dao.User, error)
+	FindUserById(ctx context.Context, id int) (dao.User, error)
+	FindUserByEmail(ctx context.Context, email string) (dao.User, error)
+	DeleteUserById(ctx context.Context, id int) error
+	FindUserByIdForUpdate(ctx context.Context, id int) (dao.User, error)
+	UpdateLockout(ctx context.Context, id, failedLogins int, lockedUntil *time.Time) error
+	UpdateMfa(ctx context.Context, id int, secret string, enabledAt *time.Time) error
+	UpdateMfaLastStep(ctx context.Context, id int, step int64) error
 }
 
 type UserRepositoryImpl struct {
@@ -24,15 +29,15 @@ type UserRepositoryImpl struct {
 
 // Save stores the user to the database.
 // It returns the saved dao.User and an error, if any.
-func (u UserRepositoryImpl) Save(request *dao.User) (dao.User, error) {
-	err := u.db.Save(request).Error
+func (u UserRepositoryImpl) Save(ctx context.Context, request *dao.User) (dao.User, error) {
+	err := u.db.WithContext(ctx).Save(request).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
-			log.Info("Error saving user: ", err)
+			pkg.Logger(ctx).Info("Error saving user: ", err)
 			return dao.User{}, pkg.NewConflictError("Email already used", err)
 		}
 
-		log.Error("Error saving user: ", err)
+		pkg.Logger(ctx).Error("Error saving user: ", err)
 		return dao.User{}, err
 	}
 
@@ -41,12 +46,12 @@ func (u UserRepositoryImpl) Save(request *dao.User) (dao.User, error) {
 
 // FindAllUser retrieves all users from the database.
 // It returns a slice of dao.User and an error, if any.
-func (u UserRepositoryImpl) FindAllUser() ([]dao.User, error) {
+func (u UserRepositoryImpl) FindAllUser(ctx context.Context) ([]dao.User, error) {
 	var users []dao.User
 
-	err := u.db.Select("id, email, role_id").Find(&users).Error
+	err := u.db.WithContext(ctx).Select("id, email, role_id, email_verified_at, mfa_enabled_at").Find(&users).Error
 	if err != nil {
-		log.Error("Error finding all users: ", err)
+		pkg.Logger(ctx).Error("Error finding all users: ", err)
 		return nil, err
 	}
 
@@ -55,17 +60,36 @@ func (u UserRepositoryImpl) FindAllUser() ([]dao.User, error) {
 
 // FindUserById retrieves a user by the given ID from the database.
 // It returns the dao.User and an error, if any.
-func (u UserRepositoryImpl) FindUserById(id int) (dao.User, error) {
+func (u UserRepositoryImpl) FindUserById(ctx context.Context, id int) (dao.User, error) {
 	user := dao.User{ID: id}
 
-	err := u.db.First(&user).Error
+	err := u.db.WithContext(ctx).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Info("Error finding user by ID: ", err)
+			pkg.Logger(ctx).Info("Error finding user by ID: ", err)
 			return dao.User{}, pkg.NewNotFoundError("User not found", err)
 		}
 
-		log.Error("Error finding user by ID: ", err)
+		pkg.Logger(ctx).Error("Error finding user by ID: ", err)
+		return dao.User{}, err
+	}
+
+	return user, nil
+}
+
+// FindUserByEmail retrieves a user by the given email from the database.
+// It returns the dao.User and an error, if any.
+func (u UserRepositoryImpl) FindUserByEmail(ctx context.Context, email string) (dao.User, error) {
+	var user dao.User
+
+	err := u.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			pkg.Logger(ctx).Info("Error finding user by email: ", err)
+			return dao.User{}, pkg.NewNotFoundError("User not found", err)
+		}
+
+		pkg.Logger(ctx).Error("Error finding user by email: ", err)
 		return dao.User{}, err
 	}
 
@@ -74,46 +98,83 @@ func (u UserRepositoryImpl) FindUserById(id int) (dao.User, error) {
 
 // DeleteUserById deletes the user by the given ID from the database.
 // It returns an error if the deletion fails.
-func (u UserRepositoryImpl) DeleteUserById(id int) error {
-	err := u.db.Delete(&dao.User{}, id).Error
+func (u UserRepositoryImpl) DeleteUserById(ctx context.Context, id int) error {
+	err := u.db.WithContext(ctx).Delete(&dao.User{}, id).Error
 	if err != nil {
-		log.Error("Error deleting user: ", err)
+		pkg.Logger(ctx).Error("Error deleting user: ", err)
 		return err
 	}
 
 	return nil
 }
 
-// VerifyUser verifies the user's credentials by checking the provided email and password.
-// It returns the found dao.User if credentials are valid, or an error otherwise.
-func (u UserRepositoryImpl) VerifyUser(request dao.User) (dao.User, error) {
-	var foundUser dao.User
+// FindUserByIdForUpdate retrieves a user by the given ID and locks the row until the surrounding transaction ends,
+// so that concurrent logins of the same user are serialized.
+// It returns the dao.User and an error, if any.
+func (u UserRepositoryImpl) FindUserByIdForUpdate(ctx context.Context, id int) (dao.User, error) {
+	user := dao.User{ID: id}
 
-	err := u.db.Where("email = ?", request.Email).First(&foundUser).Error
+	err := u.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			log.Info("Error verifying user: ", err)
-			return dao.User{}, pkg.NewUnauthorizedError("Invalid credentials", err)
+			pkg.Logger(ctx).Info("Error finding user by ID: ", err)
+			return dao.User{}, pkg.NewNotFoundError("User not found", err)
 		}
 
-		log.Error("Error verifying user: ", err)
+		pkg.Logger(ctx).Error("Error finding user by ID: ", err)
 		return dao.User{}, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(foundUser.Password), []byte(request.Password))
+	return user, nil
+}
+
+// UpdateLockout sets the number of consecutive failed logins of the user with the given ID
+// and the time until which the account is locked. A nil lockedUntil unlocks the account.
+// It returns an error if the update fails.
+func (u UserRepositoryImpl) UpdateLockout(ctx context.Context, id, failedLogins int, lockedUntil *time.Time) error {
+	err := u.db.WithContext(ctx).Model(&dao.User{ID: id}).
+		Updates(map[string]interface{}{"failed_logins": failedLogins, "locked_until": lockedUntil}).Error
 	if err != nil {
-		log.Info("Error verifying user: ", err)
-		return dao.User{}, pkg.NewUnauthorizedError("Invalid credentials", err)
+		pkg.Logger(ctx).Error("Error updating user lockout: ", err)
+		return err
 	}
 
-	return foundUser, nil
+	return nil
 }
 
-func UserRepositoryInit(db *gorm.DB) *UserRepositoryImpl {
-	if err := db.AutoMigrate(&dao.User{}); err != nil {
-		log.Fatal("Error AutoMigrating User: ", err)
+// UpdateMfa sets the TOTP secret of the user with the given ID and the time MFA was enabled.
+// A nil enabledAt leaves the secret pending activation, or turns MFA off together with an empty secret.
+// Unless MFA is being enabled, the last used TOTP step is reset, since it belongs to a previous secret.
+// It returns an error if the update fails.
+func (u UserRepositoryImpl) UpdateMfa(ctx context.Context, id int, secret string, enabledAt *time.Time) error {
+	values := map[string]interface{}{"mfa_secret": secret, "mfa_enabled_at": enabledAt}
+	if enabledAt == nil {
+		values["mfa_last_step"] = 0
+	}
+
+	err := u.db.WithContext(ctx).Model(&dao.User{ID: id}).Updates(values).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error updating user MFA: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateMfaLastStep records the TOTP time step last used by the user with the given ID,
+// so that codes of that step or earlier are not accepted again.
+// It returns an error if the update fails.
+func (u UserRepositoryImpl) UpdateMfaLastStep(ctx context.Context, id int, step int64) error {
+	err := u.db.WithContext(ctx).Model(&dao.User{ID: id}).Update("mfa_last_step", step).Error
+	if err != nil {
+		pkg.Logger(ctx).Error("Error updating user MFA step: ", err)
+		return err
 	}
 
+	return nil
+}
+
+func UserRepositoryInit(db *gorm.DB) *UserRepositoryImpl {
 	return &UserRepositoryImpl{
 		db: db,
 	}
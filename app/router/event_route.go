@@ -1,6 +1,7 @@
 package router
 
 import (
+	"event-booking-api/app/constant"
 	"event-booking-api/app/middleware"
 	"event-booking-api/config"
 
@@ -11,14 +12,18 @@ func addEventRoute(rg *gin.RouterGroup, init *config.Initialization) {
 	event := rg.Group("/events")
 
 	event.GET("", init.EventCtrl.GetAllEvent)
+	event.GET("/search", init.EventCtrl.SearchEvent)
 	event.GET("/:eventId", init.EventCtrl.GetEventById)
 
 	protected := event.Group("")
-	protected.Use(middleware.Auth)
-	protected.POST("", init.EventCtrl.AddEvent)
+	protected.Use(middleware.Auth(init.TokenSvc, init.UserSvc))
+	bookingLimit := middleware.RateLimit(init.RateLimitStore, "booking", init.Config.RateLimit.Booking, middleware.ByUser)
+	protected.POST("", middleware.RequirePermission(init.RoleSvc, constant.PermissionCreateEvent), init.EventCtrl.AddEvent)
 	protected.PUT("/:eventId", init.EventCtrl.UpdateEventById)
 	protected.DELETE("/:eventId", init.EventCtrl.DeleteEventById)
-	protected.POST("/:eventId/register", init.EventCtrl.RegisterUserForEvent)
-	protected.DELETE("/:eventId/register", init.EventCtrl.UnregisterUserForEvent)
+	protected.POST("/:eventId/register", bookingLimit, middleware.RequirePermission(init.RoleSvc, constant.PermissionRegisterEvent), init.EventCtrl.RegisterUserForEvent)
+	protected.DELETE("/:eventId/register", bookingLimit, init.EventCtrl.UnregisterUserForEvent)
+	protected.GET("/:eventId/register", init.EventCtrl.GetRegisterById)
+	protected.GET("/:eventId/register/waitlist", init.EventCtrl.GetWaitlistById)
 	protected.GET("/:eventId/attendees", init.EventCtrl.GetAttendeesEmailById)
 }
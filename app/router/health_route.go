@@ -0,0 +1,12 @@
+package router
+
+import (
+	"event-booking-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func addHealthRoute(r *gin.Engine, init *config.Initialization) {
+	r.GET("/healthz", init.HealthCtrl.GetLiveness)
+	r.GET("/readyz", init.HealthCtrl.GetReadiness)
+}
@@ -0,0 +1,11 @@
+package router
+
+import (
+	"event-booking-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func addMetricsRoute(r *gin.Engine, init *config.Initialization) {
+	r.GET("/metrics", gin.WrapH(init.Metrics.Handler()))
+}
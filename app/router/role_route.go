@@ -0,0 +1,18 @@
+package router
+
+import (
+	"event-booking-api/app/constant"
+	"event-booking-api/app/middleware"
+	"event-booking-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func addRoleRoute(rg *gin.RouterGroup, init *config.Initialization) {
+	role := rg.Group("/roles")
+
+	protected := role.Group("")
+	protected.Use(middleware.Auth(init.TokenSvc, init.UserSvc))
+	protected.GET("", middleware.RequirePermission(init.RoleSvc, constant.PermissionReadRoles), init.RoleCtrl.GetAllRole)
+	protected.PUT("/:roleId/mfa", middleware.RequirePermission(init.RoleSvc, constant.PermissionManageRoles), init.RoleCtrl.UpdateRoleMfaById)
+}
@@ -1,20 +1,36 @@
 package router
 
 import (
+	"event-booking-api/app/middleware"
 	"event-booking-api/config"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
 )
 
 func Init(init *config.Initialization) *gin.Engine {
 
 	router := gin.New()
-	router.Use(gin.Logger())
+	if err := router.SetTrustedProxies(init.Config.Server.TrustedProxies); err != nil {
+		log.Fatal("Error setting trusted proxies: ", err)
+	}
+
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.AccessLog())
+	router.Use(middleware.Metrics(init.Metrics))
 	router.Use(gin.Recovery())
+	router.Use(middleware.ErrorHandler)
+	router.Use(middleware.Timeout(init.Config.Database.Timeout))
+
+	addWellKnownRoute(router, init)
+	addHealthRoute(router, init)
+	addMetricsRoute(router, init)
 
 	api := router.Group("/api")
 	addUserRoute(api, init)
 	addEventRoute(api, init)
+	addRoleRoute(api, init)
 
 	return router
 }
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/pkg"
+	"net"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NewServer returns the HTTP server for the handler, configured with the timeouts of the configuration.
+func NewServer(cfg pkg.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+}
+
+// Serve serves requests on the listener until ctx is done, then shuts the server down gracefully:
+// it stops accepting connections and waits up to shutdownTimeout for in-flight requests to complete
+// before closing the remaining connections.
+// It returns an error if the server fails or the in-flight requests do not complete in time.
+func Serve(ctx context.Context, srv *http.Server, listener net.Listener, shutdownTimeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+
+	log.Info("Server listening on ", listener.Addr())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info("Shutting down server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Error("Error shutting down server gracefully: ", err)
+		return errors.Join(err, srv.Close())
+	}
+
+	log.Info("Server stopped")
+	return nil
+}
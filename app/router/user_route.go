@@ -1,6 +1,7 @@
 package router
 
 import (
+	"event-booking-api/app/constant"
 	"event-booking-api/app/middleware"
 	"event-booking-api/config"
 
@@ -9,14 +10,32 @@ import (
 
 func addUserRoute(rg *gin.RouterGroup, init *config.Initialization) {
 	user := rg.Group("/users")
+	authLimit := middleware.RateLimit(init.RateLimitStore, "auth", init.Config.RateLimit.Auth, middleware.ByIP)
+	mfaLimit := middleware.RateLimit(init.RateLimitStore, "mfa", init.Config.RateLimit.Auth, middleware.ByUser)
 
-	user.POST("", init.UserCtrl.AddUser)
-	user.POST("/login", init.UserCtrl.LoginUser)
+	user.POST("", authLimit, init.UserCtrl.AddUser)
+	user.POST("/login", authLimit, init.UserCtrl.LoginUser)
+	user.POST("/login/mfa", authLimit, init.UserCtrl.VerifyMfaLogin)
+	user.POST("/token/refresh", authLimit, init.UserCtrl.RefreshToken)
+	user.POST("/password/reset", authLimit, init.UserCtrl.RequestPasswordReset)
+	user.POST("/password/reset/confirm", authLimit, init.UserCtrl.ResetPassword)
+	user.GET("/verify", init.UserCtrl.VerifyEmail)
+	user.GET("/oidc/login", authLimit, init.OidcCtrl.LoginOidc)
+	user.GET("/oidc/callback", authLimit, init.OidcCtrl.OidcCallback)
 
 	protected := user.Group("")
-	protected.Use(middleware.Auth)
-	protected.GET("", init.UserCtrl.GetAllUser)
+	protected.Use(middleware.Auth(init.TokenSvc, init.UserSvc))
+	protected.POST("/logout", init.UserCtrl.LogoutUser)
+	protected.POST("/verify/resend", init.UserCtrl.ResendVerification)
+	protected.POST("/mfa/enroll", init.MfaCtrl.EnrollMfa)
+	protected.POST("/mfa/activate", mfaLimit, init.MfaCtrl.ActivateMfa)
+	protected.POST("/mfa/disable", mfaLimit, init.MfaCtrl.DisableMfa)
+	protected.POST("/mfa/recovery-codes", mfaLimit, init.MfaCtrl.RegenerateRecoveryCodes)
+	protected.GET("", middleware.RequirePermission(init.RoleSvc, constant.PermissionReadUsers), init.UserCtrl.GetAllUser)
 	protected.GET("/:userId", init.UserCtrl.GetUserById)
 	protected.PUT("/:userId", init.UserCtrl.UpdateUserById)
 	protected.DELETE("/:userId", init.UserCtrl.DeleteUserById)
+	protected.PUT("/:userId/role", middleware.RequirePermission(init.RoleSvc, constant.PermissionAssignRole), init.UserCtrl.UpdateUserRoleById)
+	protected.POST("/:userId/unlock", middleware.RequirePermission(init.RoleSvc, constant.PermissionUnlockUsers), init.UserCtrl.UnlockUserById)
+	protected.GET("/:userId/logins", init.UserCtrl.GetLoginHistoryById)
 }
@@ -0,0 +1,13 @@
+package router
+
+import (
+	"event-booking-api/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func addWellKnownRoute(r *gin.Engine, init *config.Initialization) {
+	wellKnown := r.Group("/.well-known")
+
+	wellKnown.GET("/jwks.json", init.KeyCtrl.GetJWKS)
+}
@@ -1,57 +1,121 @@
 package service
 
 import (
+	"context"
+	"event-booking-api/app/constant"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/metrics"
 	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
-
-	log "github.com/sirupsen/logrus"
+	"event-booking-api/app/tracing"
 )
 
+const defaultEventLimit = 20
+
 type EventService interface {
-	AddEvent(request dao.Event) (dao.Event, error)
-	GetAllEvent() ([]dao.Event, error)
-	GetEventById(eventId int) (dao.Event, error)
-	UpdateEventById(request dao.Event, eventId, userId int) (dao.Event, error)
-	DeleteEventById(eventId, userId int) error
+	AddEvent(ctx context.Context, request dao.Event) (dao.Event, error)
+	GetAllEvent(ctx context.Context, query dto.EventQuery) ([]dao.Event, dto.Pagination, error)
+	SearchEvent(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error)
+	GetEventById(ctx context.Context, eventId int) (dao.Event, error)
+	UpdateEventById(ctx context.Context, request dao.EventUpdateRequest, eventId, userId, roleId int) (dao.Event, error)
+	DeleteEventById(ctx context.Context, eventId, userId, roleId int) error
 }
 
 type EventServiceImpl struct {
 	eventRepo repository.EventRepository
+	roleRepo  repository.RoleRepository
+	userRepo  repository.UserRepository
+	txManager repository.TransactionManager
+	metrics   *metrics.Metrics
 }
 
 // AddEvent adds a new event to the repository.
+// Only users with a verified email can add events.
 // It returns the added dao.Event and an error if the operation fails.
-func (e EventServiceImpl) AddEvent(request dao.Event) (dao.Event, error) {
-	log.Info("Start to execute add event")
+func (e EventServiceImpl) AddEvent(ctx context.Context, request dao.Event) (dao.Event, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.AddEvent")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute add event")
+
+	err := checkEmailVerified(ctx, e.userRepo, request.UserID)
+	if err != nil {
+		return dao.Event{}, err
+	}
 
-	event, err := e.eventRepo.Save(&request)
+	event, err := e.eventRepo.Save(ctx, &request)
 	if err != nil {
 		return dao.Event{}, err
 	}
 
+	e.metrics.EventCreated()
+
 	return event, nil
 }
 
-// GetAllEvent retrieves all events from the repository.
-// It returns a slice of dao.Event and an error if the operation fails.
-func (e EventServiceImpl) GetAllEvent() ([]dao.Event, error) {
-	log.Info("Start to execute get all event")
+// GetAllEvent retrieves a page of events matching the query from the repository.
+// Unset paging and sorting options default to the first page of events ordered by event time.
+// It returns a slice of dao.Event, the dto.Pagination of the page and an error if the operation fails.
+func (e EventServiceImpl) GetAllEvent(ctx context.Context, query dto.EventQuery) ([]dao.Event, dto.Pagination, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.GetAllEvent")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get all event")
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultEventLimit
+	}
+	if query.Sort == "" {
+		query.Sort = "event_time"
+	}
+	if query.Order == "" {
+		query.Order = "asc"
+	}
+
+	events, pagination, err := e.eventRepo.FindAllEvent(ctx, query)
+	if err != nil {
+		return nil, dto.Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
+
+// SearchEvent retrieves a page of events matching the search query from the repository, ordered by relevance.
+// It returns a slice of dao.EventSearchResult, the dto.Pagination of the page and an error if the operation fails.
+func (e EventServiceImpl) SearchEvent(ctx context.Context, query dto.EventSearchQuery) ([]dao.EventSearchResult, dto.Pagination, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.SearchEvent")
+	defer span.End()
 
-	events, err := e.eventRepo.FindAllEvent()
+	pkg.Logger(ctx).Info("Start to execute search event")
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultEventLimit
+	}
+
+	results, pagination, err := e.eventRepo.SearchEvent(ctx, query)
 	if err != nil {
-		return nil, err
+		return nil, dto.Pagination{}, err
 	}
 
-	return events, nil
+	return results, pagination, nil
 }
 
 // GetEventById retrieves a event from the repository by their ID.
 // It returns the dao.Event with the specified ID and an error if the operation fails.
-func (e EventServiceImpl) GetEventById(eventId int) (dao.Event, error) {
-	log.Info("Start to execute get event by id")
+func (e EventServiceImpl) GetEventById(ctx context.Context, eventId int) (dao.Event, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.GetEventById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get event by id")
 
-	event, err := e.eventRepo.FindEventById(eventId)
+	event, err := e.eventRepo.FindEventById(ctx, eventId)
 	if err != nil {
 		return dao.Event{}, err
 	}
@@ -60,69 +124,127 @@ func (e EventServiceImpl) GetEventById(eventId int) (dao.Event, error) {
 }
 
 // UpdateEventById updates a event's details by their ID.
-// Access is restricted to the resource owner.
-// It modifies the event's name, description, location, event time if provided in the request.
+// Access is restricted to the resource owner or roles allowed to manage events.
+// It modifies the event's name, description, location, event time, capacity if provided in the request.
+// A capacity of 0 makes the event unlimited; a capacity below the confirmed registrations is rejected.
 // It returns the updated dao.Event and an error if the operation fails.
-func (e EventServiceImpl) UpdateEventById(request dao.Event, eventId, userId int) (dao.Event, error) {
-	log.Info("Start to execute update event by id")
-
-	event, err := e.eventRepo.FindEventById(eventId)
+func (e EventServiceImpl) UpdateEventById(ctx context.Context, request dao.EventUpdateRequest, eventId, userId, roleId int) (dao.Event, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.UpdateEventById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute update event by id")
+
+	// The event is read under its row lock, so that concurrent updates do not overwrite each other.
+	var event dao.Event
+	err := e.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		event, err = repos.Event.FindEventByIdForUpdate(ctx, eventId)
+		if err != nil {
+			return err
+		}
+
+		err = checkEventAccess(ctx, e.roleRepo, event, userId, roleId, constant.PermissionManageEvent)
+		if err != nil {
+			return err
+		}
+
+		if request.Name != "" {
+			event.Name = request.Name
+		}
+		if request.Description != "" {
+			event.Description = request.Description
+		}
+		if request.Location != "" {
+			event.Location = request.Location
+		}
+		if !request.EventTime.IsZero() {
+			event.EventTime = request.EventTime
+		}
+
+		if request.Capacity != nil {
+			// The capacity is checked against the confirmed registrations, which waitlisted registrations
+			// are promoted to fill.
+			err = repos.Register.UpdateCapacity(ctx, eventId, *request.Capacity)
+			if err != nil {
+				return err
+			}
+			event.Capacity = *request.Capacity
+		}
+
+		event, err = repos.Event.Save(ctx, &event)
+		return err
+	})
 	if err != nil {
 		return dao.Event{}, err
 	}
 
-	if event.UserID != userId {
-		log.Info("Access denied. Not a resource owner")
-		return dao.Event{}, pkg.NewUnauthorizedError("Unauthorized", nil)
-	}
+	return event, nil
+}
 
-	if request.Name != "" {
-		event.Name = request.Name
-	}
-	if request.Description != "" {
-		event.Description = request.Description
-	}
-	if request.Location != "" {
-		event.Location = request.Location
-	}
-	if !request.EventTime.IsZero() {
-		event.EventTime = request.EventTime
-	}
+// DeleteEventById removes a event from the repository by their ID, together with its registrations.
+// Access is restricted to the resource owner or roles allowed to manage events.
+// It returns an error if the operation fails.
+func (e EventServiceImpl) DeleteEventById(ctx context.Context, eventId, userId, roleId int) error {
+	ctx, span := tracing.Tracer().Start(ctx, "EventService.DeleteEventById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute delete event by id")
 
-	event, err = e.eventRepo.Save(&event)
+	event, err := e.eventRepo.FindEventById(ctx, eventId)
 	if err != nil {
-		return dao.Event{}, err
+		return err
 	}
 
-	return event, nil
-}
+	err = checkEventAccess(ctx, e.roleRepo, event, userId, roleId, constant.PermissionManageEvent)
+	if err != nil {
+		return err
+	}
 
-// DeleteEventById removes a event from the repository by their ID.
-// Access is restricted to the resource owner.
-// It returns an error if the operation fails.
-func (e EventServiceImpl) DeleteEventById(eventId, userId int) error {
-	log.Info("Start to execute delete event by id")
+	err = e.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		err := repos.Register.DeleteByEventId(ctx, eventId)
+		if err != nil {
+			return err
+		}
 
-	event, err := e.eventRepo.FindEventById(eventId)
+		return repos.Event.DeleteEventById(ctx, eventId)
+	})
 	if err != nil {
 		return err
 	}
 
-	if event.UserID != userId {
-		log.Info("Access denied. Not a resource owner")
-		return pkg.NewUnauthorizedError("Unauthorized", nil)
+	return nil
+}
+
+// checkEventAccess verifies that the user is the event owner or has a role granted the permission on any event.
+// It returns an unauthorized error if access is denied.
+func checkEventAccess(ctx context.Context, roleRepo repository.RoleRepository, event dao.Event, userId, roleId int, permission string) error {
+	if event.UserID == userId {
+		return nil
 	}
 
-	err = e.eventRepo.DeleteEventById(eventId)
+	allowed, err := roleRepo.HasPermission(ctx, roleId, permission)
 	if err != nil {
 		return err
 	}
 
+	if !allowed {
+		pkg.Logger(ctx).Info("Access denied. Not a resource owner")
+		return pkg.NewUnauthorizedError("Unauthorized", nil)
+	}
+
 	return nil
 }
 
-func EventServiceInit(eventRepository repository.EventRepository) *EventServiceImpl {
+func EventServiceInit(eventRepository repository.EventRepository,
+	roleRepository repository.RoleRepository,
+	userRepository repository.UserRepository,
+	txManager repository.TransactionManager,
+	metrics *metrics.Metrics) *EventServiceImpl {
 	return &EventServiceImpl{
 		eventRepo: eventRepository,
+		roleRepo:  roleRepository,
+		userRepo:  userRepository,
+		txManager: txManager,
+		metrics:   metrics,
 	}
 }
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/repository"
+	"event-booking-api/app/tracing"
+	"fmt"
+	"time"
+)
+
+// healthCheckTimeout bounds each dependency check, so that a hanging dependency reports as down.
+const healthCheckTimeout = 2 * time.Second
+
+// The readiness endpoint is public, so failed checks report these messages and leave the details to the log.
+const (
+	healthErrorDatabase   = "database unreachable"
+	healthErrorMigrations = "migration status unavailable"
+)
+
+type HealthService interface {
+	CheckLiveness() dto.Health
+	CheckReadiness(ctx context.Context) dto.Health
+}
+
+type HealthServiceImpl struct {
+	healthRepo repository.HealthRepository
+}
+
+// CheckLiveness reports that the process is running, without checking any dependency.
+func (h HealthServiceImpl) CheckLiveness() dto.Health {
+	return newHealth()
+}
+
+// CheckReadiness checks every dependency needed to serve requests: the database must be reachable
+// and all schema migrations applied.
+// It returns the dto.Health with the status of each dependency; its status is down if any dependency is down.
+func (h HealthServiceImpl) CheckReadiness(ctx context.Context) dto.Health {
+	ctx, span := tracing.Tracer().Start(ctx, "HealthService.CheckReadiness")
+	defer span.End()
+
+	health := newHealth()
+	health.Checks = map[string]dto.HealthCheck{
+		"database":   h.checkDatabase(ctx),
+		"migrations": h.checkMigrations(ctx),
+	}
+
+	for _, check := range health.Checks {
+		if check.Status != dto.HealthStatusUp {
+			health.Status = dto.HealthStatusDown
+		}
+	}
+
+	return health
+}
+
+func (h HealthServiceImpl) checkDatabase(ctx context.Context) dto.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.healthRepo.Ping(ctx)
+	if err != nil {
+		return dto.HealthCheck{Status: dto.HealthStatusDown, Error: healthErrorDatabase}
+	}
+
+	return dto.HealthCheck{
+		Status:  dto.HealthStatusUp,
+		Details: map[string]interface{}{"latency_ms": time.Since(start).Milliseconds()},
+	}
+}
+
+func (h HealthServiceImpl) checkMigrations(ctx context.Context) dto.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	statuses, err := h.healthRepo.FindMigrationStatus(ctx)
+	if err != nil {
+		return dto.HealthCheck{Status: dto.HealthStatusDown, Error: healthErrorMigrations}
+	}
+
+	var version int64
+	pending := []string{}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration.String())
+		} else {
+			version = status.Version
+		}
+	}
+
+	check := dto.HealthCheck{
+		Status:  dto.HealthStatusUp,
+		Details: map[string]interface{}{"version": version, "pending": pending},
+	}
+
+	if len(pending) > 0 {
+		check.Status = dto.HealthStatusDown
+		check.Error = fmt.Sprintf("%d migrations not applied", len(pending))
+	}
+
+	return check
+}
+
+func newHealth() dto.Health {
+	return dto.Health{
+		Status:  dto.HealthStatusUp,
+		Version: pkg.Version,
+		Commit:  pkg.BuildCommit(),
+	}
+}
+
+func HealthServiceInit(healthRepository repository.HealthRepository) *HealthServiceImpl {
+	return &HealthServiceImpl{
+		healthRepo: healthRepository,
+	}
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/repository"
+	"event-booking-api/app/tracing"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+type MfaService interface {
+	EnrollMfa(ctx context.Context, userId int) (dao.MfaEnrollmentResponse, error)
+	ActivateMfa(ctx context.Context, userId int, code string) ([]string, error)
+	DisableMfa(ctx context.Context, userId int, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userId int, code string) ([]string, error)
+}
+
+type MfaServiceImpl struct {
+	userRepo  repository.UserRepository
+	roleRepo  repository.RoleRepository
+	txManager repository.TransactionManager
+	secretBox *pkg.SecretBox
+}
+
+const (
+	mfaIssuer         = "Event Booking API"
+	mfaPeriod         = 30
+	mfaSkew           = 1
+	mfaChallengeTTL   = 5 * time.Minute
+	recoveryCodeCount = 10
+)
+
+var (
+	recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+	totpSecretEncoding   = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// EnrollMfa generates a new TOTP secret for the user, pending activation with ActivateMfa.
+// Enrolling again before activating replaces the pending secret.
+// It returns the secret with its provisioning URI and an error if MFA is already enabled or the operation fails.
+func (m MfaServiceImpl) EnrollMfa(ctx context.Context, userId int) (dao.MfaEnrollmentResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "MfaService.EnrollMfa")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute enroll MFA")
+
+	user, err := m.userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return dao.MfaEnrollmentResponse{}, err
+	}
+
+	if user.MfaEnabledAt != nil {
+		return dao.MfaEnrollmentResponse{}, pkg.NewConflictError("MFA already enabled", nil)
+	}
+
+	return startMfaEnrollment(ctx, m.userRepo, m.secretBox, user)
+}
+
+// ActivateMfa enables MFA for the user once they prove, with a code from their authenticator,
+// that they have stored the secret from EnrollMfa.
+// It returns the user's new recovery codes and an error if the code is invalid or the operation fails.
+func (m MfaServiceImpl) ActivateMfa(ctx context.Context, userId int, code string) ([]string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "MfaService.ActivateMfa")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute activate MFA")
+
+	var recoveryCodes []string
+	err := m.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		user, err := repos.User.FindUserByIdForUpdate(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		if user.MfaEnabledAt != nil {
+			return pkg.NewConflictError("MFA already enabled", nil)
+		}
+
+		if user.MfaSecret == "" {
+			return pkg.NewInvalidRequestError("MFA enrollment not started", nil)
+		}
+
+		valid, err := verifyMfaCode(ctx, repos, m.secretBox, &user, code)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			pkg.Logger(ctx).Info("Invalid MFA code")
+			return pkg.NewUnauthorizedError("Invalid MFA code", nil)
+		}
+
+		recoveryCodes, err = enableMfa(ctx, repos, user, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableMfa turns MFA off for the user and deletes their recovery codes,
+// after checking a TOTP or recovery code. Users whose role requires MFA cannot turn it off.
+// It returns an error if the code is invalid or the operation fails.
+func (m MfaServiceImpl) DisableMfa(ctx context.Context, userId int, code string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "MfaService.DisableMfa")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute disable MFA")
+
+	user, err := m.userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	role, err := m.roleRepo.FindRoleById(ctx, user.RoleID)
+	if err != nil {
+		return err
+	}
+
+	if role.MfaRequired {
+		return pkg.NewConflictError("MFA is required for role "+role.Role, nil)
+	}
+
+	return m.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		user, err := verifyEnabledMfa(ctx, repos, m.secretBox, userId, code)
+		if err != nil {
+			return err
+		}
+
+		err = repos.User.UpdateMfa(ctx, user.ID, "", nil)
+		if err != nil {
+			return err
+		}
+
+		return repos.RecoveryCode.DeleteByUserId(ctx, user.ID)
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, after checking a TOTP or recovery code.
+// It returns the new recovery codes and an error if the code is invalid or the operation fails.
+func (m MfaServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userId int, code string) ([]string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "MfaService.RegenerateRecoveryCodes")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute regenerate recovery codes")
+
+	var recoveryCodes []string
+	err := m.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		user, err := verifyEnabledMfa(ctx, repos, m.secretBox, userId, code)
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes, err = replaceRecoveryCodes(ctx, repos, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// verifyEnabledMfa locks the user by the given ID within the transaction and checks the code
+// against their enabled MFA.
+// It returns the dao.User and an error if MFA is not enabled, the code is invalid or the operation fails.
+func verifyEnabledMfa(ctx context.Context, repos repository.Repositories, secretBox *pkg.SecretBox, userId int, code string) (dao.User, error) {
+	user, err := repos.User.FindUserByIdForUpdate(ctx, userId)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	if user.MfaEnabledAt == nil {
+		return dao.User{}, pkg.NewInvalidRequestError("MFA not enabled", nil)
+	}
+
+	valid, err := verifyMfaCode(ctx, repos, secretBox, &user, code)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	if !valid {
+		pkg.Logger(ctx).Info("Invalid MFA code")
+		return dao.User{}, pkg.NewUnauthorizedError("Invalid MFA code", nil)
+	}
+
+	return user, nil
+}
+
+// startMfaEnrollment generates a new TOTP secret for the user and stores it, encrypted, pending activation.
+// It returns the secret with its provisioning URI and an error, if any.
+func startMfaEnrollment(ctx context.Context, userRepo repository.UserRepository, secretBox *pkg.SecretBox, user dao.User) (dao.MfaEnrollmentResponse, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      mfaIssuer,
+		AccountName: user.Email,
+		Period:      mfaPeriod,
+	})
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating TOTP secret: ", err)
+		return dao.MfaEnrollmentResponse{}, err
+	}
+
+	sealedSecret, err := secretBox.Seal(key.Secret())
+	if err != nil {
+		pkg.Logger(ctx).Error("Error encrypting TOTP secret: ", err)
+		return dao.MfaEnrollmentResponse{}, err
+	}
+
+	err = userRepo.UpdateMfa(ctx, user.ID, sealedSecret, nil)
+	if err != nil {
+		return dao.MfaEnrollmentResponse{}, err
+	}
+
+	return dao.MfaEnrollmentResponse{
+		Secret:          key.Secret(),
+		ProvisioningURI: key.URL(),
+	}, nil
+}
+
+// enableMfa activates the user's pending TOTP secret and issues their recovery codes.
+// It returns the recovery codes and an error, if any.
+func enableMfa(ctx context.Context, repos repository.Repositories, user dao.User, now time.Time) ([]string, error) {
+	err := repos.User.UpdateMfa(ctx, user.ID, user.MfaSecret, &now)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg.Logger(ctx).Info("MFA enabled")
+	return replaceRecoveryCodes(ctx, repos, user.ID)
+}
+
+// verifyMfaCode checks a six-digit code against the user's TOTP secret, decrypted with secretBox, or any other
+// code against their unused recovery codes. A matching TOTP step or recovery code is used up, so it cannot be replayed.
+// A secret still stored in plain is encrypted in place, and user is updated with the encrypted secret.
+// It returns true if the code is valid and an error, if any.
+func verifyMfaCode(ctx context.Context, repos repository.Repositories, secretBox *pkg.SecretBox, user *dao.User, code string) (bool, error) {
+	if isTotpCode(code) {
+		if user.MfaSecret == "" {
+			return false, nil
+		}
+
+		secret, err := openMfaSecret(ctx, repos, secretBox, user)
+		if err != nil {
+			return false, err
+		}
+
+		step, valid := validateTotp(secret, code, user.MfaLastStep, time.Now())
+		if !valid {
+			return false, nil
+		}
+
+		return true, repos.User.UpdateMfaLastStep(ctx, user.ID, step)
+	}
+
+	if user.MfaEnabledAt == nil {
+		return false, nil
+	}
+
+	return repos.RecoveryCode.Use(ctx, user.ID, hashRecoveryCode(code))
+}
+
+// openMfaSecret decrypts the user's TOTP secret with secretBox. Secrets stored before they were encrypted at rest
+// are base32 in plain; such a secret is accepted and sealed in place, so that it is only ever read in plain once.
+// It returns the secret and an error if it can be neither decrypted nor read as a plain secret.
+func openMfaSecret(ctx context.Context, repos repository.Repositories, secretBox *pkg.SecretBox, user *dao.User) (string, error) {
+	secret, err := secretBox.Open(user.MfaSecret)
+	if err == nil {
+		return secret, nil
+	}
+
+	if _, decodeErr := totpSecretEncoding.DecodeString(user.MfaSecret); decodeErr != nil {
+		pkg.Logger(ctx).Error("Error decrypting TOTP secret: ", err)
+		return "", err
+	}
+
+	pkg.Logger(ctx).Info("Encrypting TOTP secret stored in plain")
+	sealedSecret, err := secretBox.Seal(user.MfaSecret)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error encrypting TOTP secret: ", err)
+		return "", err
+	}
+
+	err = repos.User.UpdateMfa(ctx, user.ID, sealedSecret, user.MfaEnabledAt)
+	if err != nil {
+		return "", err
+	}
+
+	secret = user.MfaSecret
+	user.MfaSecret = sealedSecret
+	return secret, nil
+}
+
+// validateTotp checks the code against the secret at the current time step and one step either side,
+// skipping steps up to lastStep that were already used.
+// It returns the matching time step and true if the code is valid.
+func validateTotp(secret, code string, lastStep int64, now time.Time) (int64, bool) {
+	if secret == "" {
+		return 0, false
+	}
+
+	current := now.Unix() / mfaPeriod
+	for step := max(current-mfaSkew, lastStep+1); step <= current+mfaSkew; step++ {
+		valid, err := hotp.ValidateCustom(code, uint64(step), secret, hotp.ValidateOpts{
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && valid {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// isTotpCode reports whether the code has the shape of a TOTP code rather than a recovery code.
+func isTotpCode(code string) bool {
+	if len(code) != otp.DigitsSix.Length() {
+		return false
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// replaceRecoveryCodes generates recoveryCodeCount new recovery codes for the user by the given ID,
+// invalidating the previous ones. Only their hashes are stored.
+// It returns the plain recovery codes and an error, if any.
+func replaceRecoveryCodes(ctx context.Context, repos repository.Repositories, userId int) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]dao.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			pkg.Logger(ctx).Error("Error generating recovery code: ", err)
+			return nil, err
+		}
+
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		codes[i] = code[:4] + "-" + code[4:]
+		records[i] = dao.RecoveryCode{UserID: userId, CodeHash: hashRecoveryCode(code)}
+	}
+
+	err := repos.RecoveryCode.ReplaceByUserId(ctx, userId, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// hashRecoveryCode returns the hash of the recovery code, ignoring case and separators.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return pkg.HashToken(normalized)
+}
+
+func MfaServiceInit(userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	txManager repository.TransactionManager,
+	secretBox *pkg.SecretBox) *MfaServiceImpl {
+	return &MfaServiceImpl{
+		userRepo:  userRepository,
+		roleRepo:  roleRepository,
+		txManager: txManager,
+		secretBox: secretBox,
+	}
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"event-booking-api/app/constant"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/repository"
+	"event-booking-api/app/tracing"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+type OidcService interface {
+	AuthorizationURL(ctx context.Context) (string, error)
+	Callback(ctx context.Context, code, state, ipAddress string) (dao.LoginResponse, error)
+}
+
+type OidcServiceImpl struct {
+	cfg         pkg.OIDCConfig
+	redirectURL string
+	stateRepo   repository.OidcStateRepository
+	userSvc     UserService
+	discovery   *oidcDiscovery
+}
+
+// oidcDiscovery caches the metadata of the provider. It is discovered on first use,
+// so that the application starts even while the provider is unreachable.
+type oidcDiscovery struct {
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+const oidcStateTTL = 10 * time.Minute
+
+// AuthorizationURL starts an authorization code flow with PKCE at the OpenID Connect provider.
+// The state, PKCE verifier and nonce of the flow are stored until the provider redirects back to Callback.
+// It returns the URL to redirect the user to and an error if OpenID Connect login is not configured
+// or the operation fails.
+func (o OidcServiceImpl) AuthorizationURL(ctx context.Context) (string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OidcService.AuthorizationURL")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute OIDC authorization URL")
+
+	oauth2Cfg, _, err := o.oauth2Config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating OIDC state: ", err)
+		return "", err
+	}
+
+	nonce, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating OIDC nonce: ", err)
+		return "", err
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	err = o.stateRepo.Save(ctx, &dao.OidcState{
+		StateHash:    pkg.HashToken(state),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return oauth2Cfg.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce)), nil
+}
+
+// Callback completes the authorization code flow started by AuthorizationURL. The code is exchanged with
+// the PKCE verifier of the state, and the ID token is checked against the provider keys and the nonce of the state.
+// The user is signed in with UserService.LoginOidcUser under the role mapped from their provider groups.
+// Only verified emails are accepted, since users are linked by email.
+// It returns the login response and an error if the state, code or ID token is invalid or the operation fails.
+func (o OidcServiceImpl) Callback(ctx context.Context, code, state, ipAddress string) (dao.LoginResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "OidcService.Callback")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute OIDC callback")
+
+	oauth2Cfg, provider, err := o.oauth2Config(ctx)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	flow, err := o.stateRepo.Consume(ctx, pkg.HashToken(state))
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	token, err := oauth2Cfg.Exchange(ctx, code, oauth2.VerifierOption(flow.CodeVerifier))
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			pkg.Logger(ctx).Info("Error exchanging OIDC authorization code: ", err)
+			return dao.LoginResponse{}, pkg.NewUnauthorizedError("Invalid authorization code", err)
+		}
+
+		pkg.Logger(ctx).Error("Error exchanging OIDC authorization code: ", err)
+		return dao.LoginResponse{}, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		pkg.Logger(ctx).Info("OIDC token response without ID token")
+		return dao.LoginResponse{}, pkg.NewUnauthorizedError("Missing ID token", nil)
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: o.cfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		pkg.Logger(ctx).Info("Error verifying OIDC ID token: ", err)
+		return dao.LoginResponse{}, pkg.NewUnauthorizedError("Invalid ID token", err)
+	}
+
+	if idToken.Nonce != flow.Nonce {
+		pkg.Logger(ctx).Info("OIDC ID token nonce mismatch")
+		return dao.LoginResponse{}, pkg.NewUnauthorizedError("Invalid ID token", nil)
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		pkg.Logger(ctx).Info("Error parsing OIDC ID token claims: ", err)
+		return dao.LoginResponse{}, pkg.NewUnauthorizedError("Invalid ID token", err)
+	}
+
+	email, _ := claims["email"].(string)
+	if email == "" || !isTrueClaim(claims["email_verified"]) {
+		pkg.Logger(ctx).Info("OIDC login rejected, email missing or not verified")
+		return dao.LoginResponse{}, pkg.NewUnauthorizedError("Email not verified by identity provider", nil)
+	}
+
+	return o.userSvc.LoginOidcUser(ctx, dao.OidcLogin{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Email:   strings.ToLower(email),
+		Role:    o.mapRole(groupsClaim(claims[o.cfg.GroupsClaim])),
+	}, ipAddress)
+}
+
+// oauth2Config discovers the provider on first use.
+// It returns the OAuth2 configuration of the client with the provider, and an error if OpenID Connect login
+// is not configured or the provider cannot be discovered.
+func (o OidcServiceImpl) oauth2Config(ctx context.Context) (oauth2.Config, *oidc.Provider, error) {
+	if !o.cfg.Enabled() {
+		return oauth2.Config{}, nil, pkg.NewNotFoundError("OpenID Connect login not configured", nil)
+	}
+
+	o.discovery.mu.Lock()
+	defer o.discovery.mu.Unlock()
+
+	if o.discovery.provider == nil {
+		provider, err := oidc.NewProvider(ctx, o.cfg.IssuerURL)
+		if err != nil {
+			pkg.Logger(ctx).Error("Error discovering OIDC provider: ", err)
+			return oauth2.Config{}, nil, err
+		}
+		o.discovery.provider = provider
+	}
+
+	return oauth2.Config{
+		ClientID:     o.cfg.ClientID,
+		ClientSecret: o.cfg.ClientSecret,
+		RedirectURL:  o.redirectURL,
+		Endpoint:     o.discovery.provider.Endpoint(),
+		Scopes:       o.cfg.Scopes,
+	}, o.discovery.provider, nil
+}
+
+// mapRole returns the role of the first mapping whose group is among the given groups, or USER if none matches.
+// It returns an empty role if no mapping is configured, leaving roles to be managed in the application.
+func (o OidcServiceImpl) mapRole(groups []string) string {
+	if len(o.cfg.RoleMapping) == 0 {
+		return ""
+	}
+
+	for _, mapping := range o.cfg.RoleMapping {
+		if slices.Contains(groups, mapping.Group) {
+			return mapping.Role
+		}
+	}
+
+	return constant.RoleUser
+}
+
+// groupsClaim returns the groups of the groups claim, which providers send either as a list or as a single string.
+func groupsClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		groups := make([]string, 0, len(value))
+		for _, group := range value {
+			if name, ok := group.(string); ok {
+				groups = append(groups, name)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+// isTrueClaim reports whether a boolean claim is true. Some providers send booleans as strings.
+func isTrueClaim(claim interface{}) bool {
+	switch value := claim.(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
+
+func OidcServiceInit(cfg pkg.OIDCConfig,
+	serverCfg pkg.ServerConfig,
+	oidcStateRepository repository.OidcStateRepository,
+	userService UserService) *OidcServiceImpl {
+	redirectURL := cfg.RedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimSuffix(serverCfg.BaseURL, "/") + "/api/users/oidc/callback"
+	}
+
+	return &OidcServiceImpl{
+		cfg:         cfg,
+		redirectURL: redirectURL,
+		stateRepo:   oidcStateRepository,
+		userSvc:     userService,
+		discovery:   &oidcDiscovery{},
+	}
+}
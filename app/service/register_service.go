@@ -1,32 +1,45 @@
 package service
 
 import (
+	"context"
+	"event-booking-api/app/constant"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/metrics"
 	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
-
-	log "github.com/sirupsen/logrus"
+	"event-booking-api/app/tracing"
 )
 
 type RegisterService interface {
-	RegisterUserForEvent(eventId, userId int) error
-	UnregisterUserForEvent(eventId, userId int) error
-	GetAttendeesEmailById(eventId, userId int) ([]string, error)
+	RegisterUserForEvent(ctx context.Context, eventId, userId int) (dao.Register, error)
+	UnregisterUserForEvent(ctx context.Context, eventId, userId int) error
+	GetRegisterById(ctx context.Context, eventId, userId int) (dao.Register, error)
+	GetWaitlistById(ctx context.Context, eventId, userId, roleId int) ([]dao.Register, error)
+	GetAttendeesEmailById(ctx context.Context, eventId, userId, roleId int) ([]string, error)
 }
 
 type RegisterServiceImpl struct {
 	eventRepo    repository.EventRepository
 	registerRepo repository.RegisterRepository
+	roleRepo     repository.RoleRepository
+	userRepo     repository.UserRepository
+	txManager    repository.TransactionManager
+	metrics      *metrics.Metrics
 }
 
 // RegisterUserForEvent registers a user for a specific event to the repository.
-// It returns an error if the operation fails.
-func (r RegisterServiceImpl) RegisterUserForEvent(eventId, userId int) error {
-	log.Info("Start to execute register user for event")
+// The event lookup and the registration run in one transaction. The registration is waitlisted if the event
+// is already full. Only users with a verified email can register.
+// It returns the created dao.Register and an error if the operation fails.
+func (r RegisterServiceImpl) RegisterUserForEvent(ctx context.Context, eventId, userId int) (dao.Register, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegisterService.RegisterUserForEvent")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute register user for event")
 
-	_, err := r.eventRepo.FindEventById(eventId)
+	err := checkEmailVerified(ctx, r.userRepo, userId)
 	if err != nil {
-		return err
+		return dao.Register{}, err
 	}
 
 	register := dao.Register{
@@ -34,44 +47,105 @@ func (r RegisterServiceImpl) RegisterUserForEvent(eventId, userId int) error {
 		UserID:  userId,
 	}
 
-	err = r.registerRepo.Save(&register)
+	err = r.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		_, err := repos.Event.FindEventById(ctx, eventId)
+		if err != nil {
+			return err
+		}
+
+		return repos.Register.Save(ctx, &register)
+	})
 	if err != nil {
-		return err
+		return dao.Register{}, err
 	}
 
-	return nil
+	r.metrics.RegistrationCreated(register.Status)
+
+	return register, nil
 }
 
-// UnregisterUserForEvent unregisters a user for a specific event from the repository.
+// UnregisterUserForEvent cancels a user's registration for a specific event in the repository.
+// The earliest waitlisted user is promoted if a seat becomes available.
 // It returns an error if the operation fails.
-func (r RegisterServiceImpl) UnregisterUserForEvent(eventId, userId int) error {
-	log.Info("Start to execute unregister user for event")
+func (r RegisterServiceImpl) UnregisterUserForEvent(ctx context.Context, eventId, userId int) error {
+	ctx, span := tracing.Tracer().Start(ctx, "RegisterService.UnregisterUserForEvent")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute unregister user for event")
 
-	err := r.registerRepo.Delete(eventId, userId)
+	err := r.registerRepo.Cancel(ctx, eventId, userId)
 	if err != nil {
 		return err
 	}
 
+	r.metrics.RegistrationCancelled()
+
 	return nil
 }
 
+// GetRegisterById retrieves a user's registration for a specific event from the repository.
+// It returns the dao.Register including its waitlist position and an error if the operation fails.
+func (r RegisterServiceImpl) GetRegisterById(ctx context.Context, eventId, userId int) (dao.Register, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegisterService.GetRegisterById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get register by id")
+
+	register, err := r.registerRepo.FindRegister(ctx, eventId, userId)
+	if err != nil {
+		return dao.Register{}, err
+	}
+
+	return register, nil
+}
+
+// GetWaitlistById retrieves the ordered waitlist of a specific event from the repository.
+// Access is restricted to the resource owner or roles allowed to read the attendees of any event.
+// It returns a slice of dao.Register and an error if the operation fails.
+func (r RegisterServiceImpl) GetWaitlistById(ctx context.Context, eventId, userId, roleId int) ([]dao.Register, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegisterService.GetWaitlistById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get waitlist by id")
+
+	event, err := r.eventRepo.FindEventById(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkEventAccess(ctx, r.roleRepo, event, userId, roleId, constant.PermissionReadAttendees)
+	if err != nil {
+		return nil, err
+	}
+
+	registers, err := r.registerRepo.FindWaitlistById(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	return registers, nil
+}
+
 // GetAttendeesEmailByEventID retrieves the email addresses of all the event attendees from the repository.
-// Access is restricted to the resource owner.
+// Access is restricted to the resource owner or roles allowed to read the attendees of any event.
 // It returns a slice of emails and an error if the operation fails.
-func (r RegisterServiceImpl) GetAttendeesEmailById(eventId, userId int) ([]string, error) {
-	log.Info("Start to execute get attendees email by id")
+func (r RegisterServiceImpl) GetAttendeesEmailById(ctx context.Context, eventId, userId, roleId int) ([]string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RegisterService.GetAttendeesEmailById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get attendees email by id")
 
-	event, err := r.eventRepo.FindEventById(eventId)
+	event, err := r.eventRepo.FindEventById(ctx, eventId)
 	if err != nil {
 		return nil, err
 	}
 
-	if event.UserID != userId {
-		log.Info("Access denied. Not a resource owner")
-		return nil, pkg.NewUnauthorizedError("Unauthorized", nil)
+	err = checkEventAccess(ctx, r.roleRepo, event, userId, roleId, constant.PermissionReadAttendees)
+	if err != nil {
+		return nil, err
 	}
 
-	emails, err := r.registerRepo.FindAttendeesEmailById(eventId)
+	emails, err := r.registerRepo.FindAttendeesEmailById(ctx, eventId)
 	if err != nil {
 		return nil, err
 	}
@@ -80,9 +154,17 @@ func (r RegisterServiceImpl) GetAttendeesEmailById(eventId, userId int) ([]strin
 }
 
 func RegisterServiceInit(eventRepository repository.EventRepository,
-	registerRepository repository.RegisterRepository) *RegisterServiceImpl {
+	registerRepository repository.RegisterRepository,
+	roleRepository repository.RoleRepository,
+	userRepository repository.UserRepository,
+	txManager repository.TransactionManager,
+	metrics *metrics.Metrics) *RegisterServiceImpl {
 	return &RegisterServiceImpl{
 		eventRepo:    eventRepository,
 		registerRepo: registerRepository,
+		roleRepo:     roleRepository,
+		userRepo:     userRepository,
+		txManager:    txManager,
+		metrics:      metrics,
 	}
 }
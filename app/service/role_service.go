@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/repository"
+	"event-booking-api/app/tracing"
+)
+
+type RoleService interface {
+	GetAllRole(ctx context.Context) ([]dao.Role, error)
+	HasPermission(ctx context.Context, roleId int, permission string) (bool, error)
+	UpdateRoleMfaById(ctx context.Context, roleId int, required bool) (dao.Role, error)
+}
+
+type RoleServiceImpl struct {
+	roleRepo repository.RoleRepository
+}
+
+// GetAllRole retrieves all roles and their permissions from the repository.
+// It returns a slice of dao.Role and an error if the operation fails.
+func (r RoleServiceImpl) GetAllRole(ctx context.Context) ([]dao.Role, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RoleService.GetAllRole")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get all role")
+
+	roles, err := r.roleRepo.FindAllRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// HasPermission checks whether the role by the given ID is granted the permission.
+// It returns true if the permission is granted and an error if the operation fails.
+func (r RoleServiceImpl) HasPermission(ctx context.Context, roleId int, permission string) (bool, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RoleService.HasPermission")
+	defer span.End()
+
+	return r.roleRepo.HasPermission(ctx, roleId, permission)
+}
+
+// UpdateRoleMfaById sets whether users of the role by the given ID must sign in with MFA.
+// Users of the role who have not enabled MFA are enrolled at their next login.
+// It returns the updated dao.Role and an error if the operation fails.
+func (r RoleServiceImpl) UpdateRoleMfaById(ctx context.Context, roleId int, required bool) (dao.Role, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RoleService.UpdateRoleMfaById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute update role MFA by id")
+
+	role, err := r.roleRepo.FindRoleById(ctx, roleId)
+	if err != nil {
+		return dao.Role{}, err
+	}
+
+	err = r.roleRepo.UpdateMfaRequired(ctx, role.ID, required)
+	if err != nil {
+		return dao.Role{}, err
+	}
+
+	role.MfaRequired = required
+
+	return role, nil
+}
+
+func RoleServiceInit(roleRepository repository.RoleRepository) *RoleServiceImpl {
+	return &RoleServiceImpl{
+		roleRepo: roleRepository,
+	}
+}
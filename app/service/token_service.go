@@ -0,0 +1,217 @@
+package service
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+type TokenService interface {
+	SignToken(claims jwt.MapClaims) (string, error)
+	ParseToken(tokenString string) (jwt.MapClaims, error)
+	GetJWKS() dto.JWKS
+}
+
+type TokenServiceImpl struct {
+	keys       []signingKey
+	hmacSecret []byte
+	hmacUntil  time.Time
+}
+
+// signingKey is an asymmetric key of the rotation schedule.
+// It signs new tokens from activeFrom until a later key becomes active, and is
+// accepted for verification and published in the JWKS until retireAt, if set.
+type signingKey struct {
+	kid        string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	activeFrom time.Time
+	retireAt   time.Time
+}
+
+// keySetFile is the format of the key set file of the JWT configuration.
+type keySetFile struct {
+	Keys []struct {
+		Kid            string    `json:"kid"`
+		PrivateKeyFile string    `json:"private_key_file"`
+		ActiveFrom     time.Time `json:"active_from"`
+		RetireAt       time.Time `json:"retire_at"`
+	} `json:"keys"`
+}
+
+// SignToken signs the claims with the currently active key of the rotation schedule, identified by its kid.
+// HS256 with JWT_SECRET_KEY is used while no asymmetric key is configured.
+// It returns the signed token and an error if no key is available or signing fails.
+func (t TokenServiceImpl) SignToken(claims jwt.MapClaims) (string, error) {
+	key, ok := t.activeKey(time.Now())
+	if !ok {
+		if len(t.hmacSecret) == 0 {
+			return "", errors.New("no signing key available")
+		}
+
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.hmacSecret)
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+
+	return token.SignedString(key.privateKey)
+}
+
+// ParseToken verifies the token signature and standard claims.
+// Asymmetric tokens are verified with the unretired key matching their kid; HS256 tokens
+// are accepted while JWT_SECRET_KEY is set and the migration window has not ended.
+// It returns the token claims and an error if the token is invalid.
+func (t TokenServiceImpl) ParseToken(tokenString string) (jwt.MapClaims, error) {
+	now := time.Now()
+
+	parsedToken, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			if len(t.hmacSecret) == 0 || (!t.hmacUntil.IsZero() && now.After(t.hmacUntil)) {
+				return nil, errors.New("HS256 tokens are no longer accepted")
+			}
+
+			return t.hmacSecret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range t.keys {
+			if key.kid == kid && key.method.Alg() == token.Method.Alg() && !key.retired(now) {
+				return key.privateKey.Public(), nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}, jwt.WithValidMethods([]string{
+		jwt.SigningMethodHS256.Alg(),
+		jwt.SigningMethodRS256.Alg(),
+		jwt.SigningMethodEdDSA.Alg(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || !parsedToken.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// GetJWKS returns the public keys of every unretired key, including keys scheduled to become active,
+// so that other services can verify tokens without sharing a secret.
+func (t TokenServiceImpl) GetJWKS() dto.JWKS {
+	now := time.Now()
+	jwks := dto.JWKS{Keys: []dto.JWK{}}
+
+	for _, key := range t.keys {
+		if key.retired(now) {
+			continue
+		}
+
+		jwk, err := pkg.NewJWK(key.kid, key.method, key.privateKey.Public())
+		if err != nil {
+			log.Error("Error converting signing key to JWK: ", err)
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// activeKey returns the most recently activated key that is not retired at the given time.
+func (t TokenServiceImpl) activeKey(now time.Time) (signingKey, bool) {
+	for i := len(t.keys) - 1; i >= 0; i-- {
+		key := t.keys[i]
+		if !key.activeFrom.After(now) && !key.retired(now) {
+			return key, true
+		}
+	}
+
+	return signingKey{}, false
+}
+
+func (k signingKey) retired(now time.Time) bool {
+	return !k.retireAt.IsZero() && !now.Before(k.retireAt)
+}
+
+// loadSigningKeys reads the key set file and the private keys it references, ordered by activation time.
+func loadSigningKeys(path string) ([]signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file keySetFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	keys := make([]signingKey, 0, len(file.Keys))
+	seen := make(map[string]bool)
+	for _, entry := range file.Keys {
+		if entry.Kid == "" || seen[entry.Kid] {
+			return nil, fmt.Errorf("missing or duplicate kid %q", entry.Kid)
+		}
+		seen[entry.Kid] = true
+
+		keyPath := entry.PrivateKeyFile
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(filepath.Dir(path), keyPath)
+		}
+
+		pemData, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, method, err := pkg.ParsePrivateKey(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", entry.Kid, err)
+		}
+
+		keys = append(keys, signingKey{
+			kid:        entry.Kid,
+			method:     method,
+			privateKey: privateKey,
+			activeFrom: entry.ActiveFrom,
+			retireAt:   entry.RetireAt,
+		})
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].activeFrom.Before(keys[j].activeFrom)
+	})
+
+	return keys, nil
+}
+
+func TokenServiceInit(cfg pkg.JWTConfig) *TokenServiceImpl {
+	tokenSvc := &TokenServiceImpl{
+		hmacSecret: []byte(cfg.SecretKey),
+		hmacUntil:  cfg.HS256AcceptUntil,
+	}
+
+	if cfg.KeysFile != "" {
+		keys, err := loadSigningKeys(cfg.KeysFile)
+		if err != nil {
+			log.Fatal("Error loading JWT signing keys: ", err)
+		}
+		tokenSvc.keys = keys
+	}
+
+	return tokenSvc
+}
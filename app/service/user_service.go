@@ -1,9 +1,16 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"event-booking-api/app/constant"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/metrics"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
-	"os"
+	"event-booking-api/app/tracing"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,40 +19,103 @@ import (
 )
 
 type UserService interface {
-	AddUser(request dao.User) (dao.User, error)
-	GetAllUser() ([]dao.User, error)
-	GetUserById(userId int) (dao.User, error)
-	UpdateUserById(request dao.User, userId int) (dao.User, error)
-	DeleteUserById(userId int) error
-	LoginUser(request dao.User) (string, error)
+	AddUser(ctx context.Context, request dao.User) (dao.User, error)
+	GetAllUser(ctx context.Context) ([]dao.User, error)
+	GetUserById(ctx context.Context, userId int) (dao.User, error)
+	UpdateUserById(ctx context.Context, request dao.User, userId int) (dao.User, error)
+	DeleteUserById(ctx context.Context, userId int) error
+	UpdateUserRoleById(ctx context.Context, userId, roleId int) (dao.User, error)
+	UnlockUserById(ctx context.Context, userId int) (dao.User, error)
+	GetLoginHistoryById(ctx context.Context, userId int) ([]dao.LoginAttempt, error)
+	LoginUser(ctx context.Context, request dao.User, ipAddress string) (dao.LoginResponse, error)
+	VerifyMfaLogin(ctx context.Context, mfaToken, code, ipAddress string) (dao.LoginResponse, error)
+	LoginOidcUser(ctx context.Context, login dao.OidcLogin, ipAddress string) (dao.LoginResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (dao.TokenResponse, error)
+	LogoutUser(ctx context.Context, sessionId string) error
+	IsSessionActive(ctx context.Context, sessionId string) (bool, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, userId int) error
 }
 
 type UserServiceImpl struct {
-	userRepo repository.UserRepository
+	userRepo          repository.UserRepository
+	roleRepo          repository.RoleRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	passwordResetRepo repository.PasswordResetRepository
+	verificationRepo  repository.EmailVerificationRepository
+	loginAttemptRepo  repository.LoginAttemptRepository
+	mfaChallengeRepo  repository.MfaChallengeRepository
+	txManager         repository.TransactionManager
+	tokenSvc          TokenService
+	notifier          pkg.Notifier
+	metrics           *metrics.Metrics
+	secretBox         *pkg.SecretBox
+	baseURL           string
 }
 
+const (
+	accessTokenTTL   = 15 * time.Minute
+	refreshTokenTTL  = 7 * 24 * time.Hour
+	passwordResetTTL = 30 * time.Minute
+
+	verificationTTL            = 24 * time.Hour
+	verificationResendInterval = time.Minute
+	verificationResendWindow   = time.Hour
+	verificationResendLimit    = 5
+
+	loginLockoutThreshold = 5
+	loginLockoutBase      = time.Minute
+	loginLockoutMax       = time.Hour
+	loginHistoryLimit     = 20
+
+	// dummyPasswordHash is a bcrypt hash with the cost of real password hashes, compared against when there is
+	// no password to check, so that rejected logins take as long as a wrong password.
+	dummyPasswordHash = "$2a$14$WWokySAWciSqlgwMCf3ISO676U6TQ34Pyi/0OiBCUTnyO0yGgTuT."
+)
+
 // AddUser adds a new user to the repository by hashing the provided password.
+// New users are always assigned the USER role; roles can only be changed through UpdateUserRoleById.
+// The email starts unverified and a verification link is sent to it.
 // It returns the added dao.User and an error if the operation fails.
-func (u UserServiceImpl) AddUser(request dao.User) (dao.User, error) {
-	log.Info("Start to execute add user")
+func (u UserServiceImpl) AddUser(ctx context.Context, request dao.User) (dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.AddUser")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute add user")
+
+	role, err := u.roleRepo.FindRoleByName(ctx, constant.RoleUser)
+	if err != nil {
+		return dao.User{}, err
+	}
+	request.RoleID = role.ID
 
 	hash, _ := bcrypt.GenerateFromPassword([]byte(request.Password), 14)
 	request.Password = string(hash)
 
-	user, err := u.userRepo.Save(&request)
+	user, err := u.userRepo.Save(ctx, &request)
 	if err != nil {
 		return dao.User{}, err
 	}
 
+	// The account is created even if the email cannot be sent; the user can request another one.
+	if err := u.sendVerification(ctx, user); err != nil {
+		pkg.Logger(ctx).Error("Error sending email verification: ", err)
+	}
+
 	return user, nil
 }
 
 // GetAllUser retrieves all users from the repository.
 // It returns a slice of dao.User and an error if the operation fails.
-func (u UserServiceImpl) GetAllUser() ([]dao.User, error) {
-	log.Info("Start to execute get all user")
+func (u UserServiceImpl) GetAllUser(ctx context.Context) ([]dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.GetAllUser")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get all user")
 
-	users, err := u.userRepo.FindAllUser()
+	users, err := u.userRepo.FindAllUser(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -55,10 +125,13 @@ func (u UserServiceImpl) GetAllUser() ([]dao.User, error) {
 
 // GetUserById retrieves a user from the repository by their ID.
 // It returns the dao.User with the specified ID and an error if the operation fails.
-func (u UserServiceImpl) GetUserById(userId int) (dao.User, error) {
-	log.Info("Start to execute get user by id")
+func (u UserServiceImpl) GetUserById(ctx context.Context, userId int) (dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.GetUserById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get user by id")
 
-	user, err := u.userRepo.FindUserById(userId)
+	user, err := u.userRepo.FindUserById(ctx, userId)
 	if err != nil {
 		return dao.User{}, err
 	}
@@ -68,37 +141,578 @@ func (u UserServiceImpl) GetUserById(userId int) (dao.User, error) {
 
 // UpdateUserById updates a user's details by their ID.
 // It modifies the user's email, password if provided in the request.
+// A changed email must be verified again, and the verification tokens sent to the previous email stop working.
 // It returns the updated dao.User and an error if the operation fails.
-func (u UserServiceImpl) UpdateUserById(request dao.User, userId int) (dao.User, error) {
-	log.Info("Start to execute update user by id")
+func (u UserServiceImpl) UpdateUserById(ctx context.Context, request dao.User, userId int) (dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.UpdateUserById")
+	defer span.End()
 
-	user, err := u.userRepo.FindUserById(userId)
+	pkg.Logger(ctx).Info("Start to execute update user by id")
+
+	user, err := u.userRepo.FindUserById(ctx, userId)
 	if err != nil {
 		return dao.User{}, err
 	}
 
-	if request.Email != "" {
+	emailChanged := request.Email != "" && request.Email != user.Email
+	if emailChanged {
 		user.Email = request.Email
+		user.EmailVerifiedAt = nil
 	}
 	if request.Password != "" {
 		hash, _ := bcrypt.GenerateFromPassword([]byte(request.Password), 14)
 		user.Password = string(hash)
 	}
 
-	user, err = u.userRepo.Save(&user)
+	err = u.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		user, err = repos.User.Save(ctx, &user)
+		if err != nil {
+			return err
+		}
+
+		if !emailChanged {
+			return nil
+		}
+
+		return repos.EmailVerification.InvalidateByUserId(ctx, userId)
+	})
 	if err != nil {
 		return dao.User{}, err
 	}
 
+	if emailChanged {
+		if err := u.sendVerification(ctx, user); err != nil {
+			pkg.Logger(ctx).Error("Error sending email verification: ", err)
+		}
+	}
+
 	return user, nil
 }
 
 // DeleteUserById removes a user from the repository by their ID.
+// Within the same transaction, the user's registrations are removed, freeing their seats for waitlisted users,
+// and all of their sessions are revoked.
+// It returns an error if the operation fails.
+func (u UserServiceImpl) DeleteUserById(ctx context.Context, userId int) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.DeleteUserById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute delete user by id")
+
+	err := u.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		err := repos.Register.DeleteByUserId(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		err = repos.RefreshToken.RevokeAllByUserId(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		return repos.User.DeleteUserById(ctx, userId)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserRoleById assigns the role by the given role ID to a user by their ID.
+// It returns the updated dao.User and an error if the role or user does not exist or the operation fails.
+func (u UserServiceImpl) UpdateUserRoleById(ctx context.Context, userId, roleId int) (dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.UpdateUserRoleById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute update user role by id")
+
+	_, err := u.roleRepo.FindRoleById(ctx, roleId)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	user, err := u.userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	user.RoleID = roleId
+
+	user, err = u.userRepo.Save(ctx, &user)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	return user, nil
+}
+
+// UnlockUserById clears the lockout of a user's account together with its count of failed logins.
+// It returns the unlocked dao.User and an error if the operation fails.
+func (u UserServiceImpl) UnlockUserById(ctx context.Context, userId int) (dao.User, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.UnlockUserById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute unlock user by id")
+
+	user, err := u.userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	err = u.userRepo.UpdateLockout(ctx, user.ID, 0, nil)
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+
+	return user, nil
+}
+
+// GetLoginHistoryById retrieves the most recent login attempts of a user, newest first.
+// It returns at most loginHistoryLimit dao.LoginAttempt and an error if the operation fails.
+func (u UserServiceImpl) GetLoginHistoryById(ctx context.Context, userId int) ([]dao.LoginAttempt, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.GetLoginHistoryById")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute get login history by id")
+
+	return u.loginAttemptRepo.FindRecentByUserId(ctx, userId, loginHistoryLimit)
+}
+
+// LoginUser verifies user credentials and starts a new session if the credentials are valid.
+// Every attempt is recorded with the client IP address. After loginLockoutThreshold consecutive failures
+// the account is locked, for a period that doubles with each further failure, and rejects even the correct
+// password until the lock expires or an admin unlocks it. A locked account is rejected as invalid credentials,
+// after the same password hashing as an unknown email or a wrong password.
+// Users with MFA, or whose role requires it, get an MFA challenge instead of a session, to be completed
+// with VerifyMfaLogin. If the role requires MFA but the user has not enabled it yet, the challenge enrolls them.
+// It returns a short-lived JWT access token together with a refresh token, or the MFA challenge,
+// and an error if the operation fails.
+func (u UserServiceImpl) LoginUser(ctx context.Context, request dao.User, ipAddress string) (dao.LoginResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.LoginUser")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to verify user login credentials")
+
+	attempt := dao.LoginAttempt{Email: request.Email, IPAddress: ipAddress}
+
+	foundUser, err := u.userRepo.FindUserByEmail(ctx, request.Email)
+	if err != nil {
+		var customErr *pkg.CustomError
+		if !errors.As(err, &customErr) || customErr.Type != constant.DataNotFound {
+			return dao.LoginResponse{}, err
+		}
+
+		burnPasswordCheck(request.Password)
+
+		if err = u.loginAttemptRepo.Save(ctx, &attempt); err != nil {
+			return dao.LoginResponse{}, err
+		}
+
+		return dao.LoginResponse{}, u.rejectLogin("Invalid credentials")
+	}
+
+	role, err := u.roleRepo.FindRoleById(ctx, foundUser.RoleID)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	var mfaPending bool
+	err = u.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		user, err := repos.User.FindUserByIdForUpdate(ctx, foundUser.ID)
+		if err != nil {
+			return err
+		}
+		foundUser = user
+		attempt.UserID = &user.ID
+
+		now := time.Now()
+		switch {
+		case isLockedOut(user, now):
+			pkg.Logger(ctx).Info("Login rejected, account locked until ", user.LockedUntil)
+			burnPasswordCheck(request.Password)
+		case bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)) == nil:
+			if user.MfaEnabledAt != nil || role.MfaRequired {
+				// The attempt is recorded once the second factor has been checked.
+				mfaPending = true
+				return nil
+			}
+
+			attempt.Success = true
+			err = resetFailedLogins(ctx, repos, user)
+		default:
+			err = recordFailedLogin(ctx, repos, user, now)
+		}
+		if err != nil {
+			return err
+		}
+
+		return repos.LoginAttempt.Save(ctx, &attempt)
+	})
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	if mfaPending {
+		return u.startMfaChallenge(ctx, foundUser)
+	}
+
+	if !attempt.Success {
+		// Locked accounts are rejected like unknown emails, so that the response does not reveal which emails exist.
+		return dao.LoginResponse{}, u.rejectLogin("Invalid credentials")
+	}
+
+	tokens, err := u.startSession(ctx, foundUser)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	return dao.LoginResponse{TokenResponse: &tokens}, nil
+}
+
+// VerifyMfaLogin completes a login started by LoginUser with a TOTP code or one of the user's recovery codes.
+// Invalid codes count as failed logins towards the account lockout, a locked account is rejected like an invalid
+// code, and the challenge can be retried until it expires. If the challenge enrolled the user, MFA is enabled and their recovery codes are issued.
+// It returns a short-lived JWT access token together with a refresh token, and an error if the operation fails.
+func (u UserServiceImpl) VerifyMfaLogin(ctx context.Context, mfaToken, code, ipAddress string) (dao.LoginResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.VerifyMfaLogin")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to verify MFA login")
+
+	challenge, err := u.mfaChallengeRepo.FindMfaChallengeByHash(ctx, pkg.HashToken(mfaToken))
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	attempt := dao.LoginAttempt{UserID: &challenge.UserID, IPAddress: ipAddress}
+
+	var user dao.User
+	var recoveryCodes []string
+	err = u.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		user, err = repos.User.FindUserByIdForUpdate(ctx, challenge.UserID)
+		if err != nil {
+			return err
+		}
+		attempt.Email = user.Email
+
+		now := time.Now()
+		if isLockedOut(user, now) {
+			pkg.Logger(ctx).Info("Login rejected, account locked until ", user.LockedUntil)
+			return repos.LoginAttempt.Save(ctx, &attempt)
+		}
+
+		valid, err := verifyMfaCode(ctx, repos, u.secretBox, &user, code)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			err = recordFailedLogin(ctx, repos, user, now)
+			if err != nil {
+				return err
+			}
+
+			return repos.LoginAttempt.Save(ctx, &attempt)
+		}
+
+		err = repos.MfaChallenge.Use(ctx, challenge.ID)
+		if err != nil {
+			return err
+		}
+
+		if user.MfaEnabledAt == nil {
+			recoveryCodes, err = enableMfa(ctx, repos, user, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = resetFailedLogins(ctx, repos, user)
+		if err != nil {
+			return err
+		}
+
+		attempt.Success = true
+		return repos.LoginAttempt.Save(ctx, &attempt)
+	})
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	if !attempt.Success {
+		return dao.LoginResponse{}, u.rejectLogin("Invalid MFA code")
+	}
+
+	tokens, err := u.startSession(ctx, user)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	return dao.LoginResponse{TokenResponse: &tokens, RecoveryCodes: recoveryCodes}, nil
+}
+
+// LoginOidcUser signs in the user authenticated by an OpenID Connect provider, without a password.
+// The provider account is linked to the user on first login: to the user with the same email if there is one,
+// otherwise to a new user. Linking an account whose email was never verified resets its password and revokes its
+// sessions, since whoever signed up with the email did not prove they own it.
+// If login.Role is set, the user is moved to that role on every login. Locked accounts stay locked and are rejected
+// as invalid credentials, and users with MFA, or whose role requires it, get an MFA challenge as with LoginUser.
+// It returns a short-lived JWT access token together with a refresh token, or the MFA challenge,
+// and an error if the operation fails.
+func (u UserServiceImpl) LoginOidcUser(ctx context.Context, login dao.OidcLogin, ipAddress string) (dao.LoginResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.LoginOidcUser")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute login OIDC user")
+
+	roleName := login.Role
+	if roleName == "" {
+		roleName = constant.RoleUser
+	}
+
+	role, err := u.roleRepo.FindRoleByName(ctx, roleName)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	attempt := dao.LoginAttempt{Email: login.Email, IPAddress: ipAddress}
+
+	var user dao.User
+	err = u.txManager.WithinTransaction(ctx, func(repos repository.Repositories) error {
+		var err error
+		user, err = findOrLinkOidcUser(ctx, repos, login, role.ID)
+		if err != nil {
+			return err
+		}
+		attempt.UserID = &user.ID
+
+		if isLockedOut(user, time.Now()) {
+			pkg.Logger(ctx).Info("Login rejected, account locked until ", user.LockedUntil)
+			return repos.LoginAttempt.Save(ctx, &attempt)
+		}
+
+		if login.Role != "" && user.RoleID != role.ID {
+			pkg.Logger(ctx).Info("Moving user to role ", role.Role, " of their provider groups")
+			user.RoleID = role.ID
+			user, err = repos.User.Save(ctx, &user)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = resetFailedLogins(ctx, repos, user)
+		if err != nil {
+			return err
+		}
+
+		attempt.Success = true
+		return repos.LoginAttempt.Save(ctx, &attempt)
+	})
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	if !attempt.Success {
+		return dao.LoginResponse{}, u.rejectLogin("Invalid credentials")
+	}
+
+	userRole := role
+	if user.RoleID != role.ID {
+		userRole, err = u.roleRepo.FindRoleById(ctx, user.RoleID)
+		if err != nil {
+			return dao.LoginResponse{}, err
+		}
+	}
+
+	if user.MfaEnabledAt != nil || userRole.MfaRequired {
+		return u.startMfaChallenge(ctx, user)
+	}
+
+	tokens, err := u.startSession(ctx, user)
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	return dao.LoginResponse{TokenResponse: &tokens}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access token and a new refresh token of the same session.
+// Each refresh token can only be used once. Presenting a token that was already used revokes the whole session,
+// since it means the token has been leaked.
+// It returns the new tokens and an error if the refresh token is invalid or the operation fails.
+func (u UserServiceImpl) RefreshToken(ctx context.Context, refreshToken string) (dao.TokenResponse, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.RefreshToken")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute refresh token")
+
+	current, err := u.refreshTokenRepo.FindRefreshTokenByHash(ctx, pkg.HashToken(refreshToken))
+	if err != nil {
+		return dao.TokenResponse{}, err
+	}
+
+	if current.RevokedAt != nil {
+		pkg.Logger(ctx).Info("Refresh token revoked. Session: ", current.FamilyID)
+		return dao.TokenResponse{}, pkg.NewUnauthorizedError("Refresh token revoked", nil)
+	}
+
+	if current.UsedAt != nil {
+		pkg.Logger(ctx).Warn("Refresh token reuse detected, revoking session: ", current.FamilyID)
+		return dao.TokenResponse{}, u.revokeReusedFamily(ctx, current.FamilyID)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		pkg.Logger(ctx).Info("Refresh token expired. Session: ", current.FamilyID)
+		return dao.TokenResponse{}, pkg.NewUnauthorizedError("Refresh token expired", nil)
+	}
+
+	user, err := u.userRepo.FindUserById(ctx, current.UserID)
+	if err != nil {
+		return dao.TokenResponse{}, err
+	}
+
+	nextToken, next, err := newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return dao.TokenResponse{}, err
+	}
+
+	err = u.refreshTokenRepo.Rotate(ctx, current, &next)
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) {
+			pkg.Logger(ctx).Warn("Refresh token reuse detected, revoking session: ", current.FamilyID)
+			return dao.TokenResponse{}, u.revokeReusedFamily(ctx, current.FamilyID)
+		}
+
+		return dao.TokenResponse{}, err
+	}
+
+	return u.issueTokens(user, current.FamilyID, nextToken)
+}
+
+// LogoutUser revokes the session by the given ID, invalidating its access and refresh tokens.
+// It returns an error if the operation fails.
+func (u UserServiceImpl) LogoutUser(ctx context.Context, sessionId string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.LogoutUser")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute logout user")
+
+	err := u.refreshTokenRepo.RevokeFamily(ctx, sessionId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsSessionActive checks whether the session by the given ID has neither been revoked nor expired.
+// It returns the result and an error if the operation fails.
+func (u UserServiceImpl) IsSessionActive(ctx context.Context, sessionId string) (bool, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.IsSessionActive")
+	defer span.End()
+
+	active, err := u.refreshTokenRepo.IsFamilyActive(ctx, sessionId)
+	if err != nil {
+		return false, err
+	}
+
+	return active, nil
+}
+
+// RequestPasswordReset sends a single-use password reset token to the user with the given email.
+// Unknown emails are ignored so that the response does not reveal which emails are registered.
 // It returns an error if the operation fails.
-func (u UserServiceImpl) DeleteUserById(userId int) error {
-	log.Info("Start to execute delete user by id")
+func (u UserServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.RequestPasswordReset")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute request password reset")
+
+	user, err := u.userRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		var customErr *pkg.CustomError
+		if errors.As(err, &customErr) && customErr.Type == constant.DataNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	token, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating password reset token: ", err)
+		return err
+	}
+
+	err = u.passwordResetRepo.Save(ctx, &dao.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.notifier.Send(pkg.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the following token to reset your password: %s\nThe token expires in %v and can only be used once.",
+			token, passwordResetTTL),
+	})
+	if err != nil {
+		pkg.Logger(ctx).Error("Error sending password reset token: ", err)
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for the user the reset token was issued to.
+// The token is consumed and every existing session of the user is revoked.
+// It returns an error if the token is invalid or expired, or the operation fails.
+func (u UserServiceImpl) ResetPassword(ctx context.Context, token, password string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.ResetPassword")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute reset password")
+
+	reset, err := u.passwordResetRepo.Consume(ctx, pkg.HashToken(token))
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.FindUserById(ctx, reset.UserID)
+	if err != nil {
+		return err
+	}
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte(password), 14)
+	user.Password = string(hash)
+
+	_, err = u.userRepo.Save(ctx, &user)
+	if err != nil {
+		return err
+	}
+
+	return u.refreshTokenRepo.RevokeAllByUserId(ctx, user.ID)
+}
+
+// VerifyEmail marks the email of the user the verification token was issued to as verified.
+// It returns an error if the token is invalid or expired, or the operation fails.
+func (u UserServiceImpl) VerifyEmail(ctx context.Context, token string) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.VerifyEmail")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute verify email")
 
-	err := u.userRepo.DeleteUserById(userId)
+	_, err := u.verificationRepo.Consume(ctx, pkg.HashToken(token))
 	if err != nil {
 		return err
 	}
@@ -106,28 +720,352 @@ func (u UserServiceImpl) DeleteUserById(userId int) error {
 	return nil
 }
 
-// LoginUser verifies user credentials and generates a JWT token if the credentials are valid.
-// It returns the JWT token and an error if the operation fails.
-func (u UserServiceImpl) LoginUser(request dao.User) (string, error) {
-	log.Info("Start to verify user login credentials")
+// ResendVerification sends a new verification link to the user by their ID.
+// Resending is limited to one link per minute and five per hour.
+// It returns an error if the email is already verified, the limit is reached or the operation fails.
+func (u UserServiceImpl) ResendVerification(ctx context.Context, userId int) error {
+	ctx, span := tracing.Tracer().Start(ctx, "UserService.ResendVerification")
+	defer span.End()
+
+	pkg.Logger(ctx).Info("Start to execute resend verification")
+
+	user, err := u.userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return pkg.NewConflictError("Email already verified", nil)
+	}
+
+	now := time.Now()
+	recent, err := u.verificationRepo.CountSince(ctx, userId, now.Add(-verificationResendInterval))
+	if err != nil {
+		return err
+	}
+
+	sent, err := u.verificationRepo.CountSince(ctx, userId, now.Add(-verificationResendWindow))
+	if err != nil {
+		return err
+	}
+
+	if recent > 0 || sent >= verificationResendLimit {
+		pkg.Logger(ctx).Info("Verification resend limit reached for user: ", userId)
+		return pkg.NewTooManyRequestsError("Verification email sent too recently", nil)
+	}
+
+	return u.sendVerification(ctx, user)
+}
+
+// sendVerification issues a verification token for the user and sends the verification link to their email.
+// It returns an error if the operation fails.
+func (u UserServiceImpl) sendVerification(ctx context.Context, user dao.User) error {
+	token, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	err = u.verificationRepo.Save(ctx, &dao.EmailVerification{
+		UserID:    user.ID,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(verificationTTL),
+	})
+	if err != nil {
+		return err
+	}
 
-	foundUser, err := u.userRepo.VerifyUser(request)
+	return u.notifier.Send(pkg.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Open the following link to verify your email: %s/api/users/verify?token=%s\nThe link expires in %v.",
+			u.baseURL, token, verificationTTL),
+	})
+}
+
+// startMfaChallenge issues a single-use MFA challenge for the user, valid for mfaChallengeTTL.
+// If the user has not enabled MFA yet, a new secret is generated to enroll them with.
+// It returns the challenge and an error, if any.
+func (u UserServiceImpl) startMfaChallenge(ctx context.Context, user dao.User) (dao.LoginResponse, error) {
+	token, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating MFA token: ", err)
+		return dao.LoginResponse{}, err
+	}
+
+	response := dao.LoginResponse{MfaRequired: true, MfaToken: token}
+	if user.MfaEnabledAt == nil {
+		pkg.Logger(ctx).Info("MFA required by role, starting enrollment")
+
+		enrollment, err := startMfaEnrollment(ctx, u.userRepo, u.secretBox, user)
+		if err != nil {
+			return dao.LoginResponse{}, err
+		}
+		response.MfaEnrollment = &enrollment
+	}
+
+	err = u.mfaChallengeRepo.Save(ctx, &dao.MfaChallenge{
+		UserID:    user.ID,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	})
+	if err != nil {
+		return dao.LoginResponse{}, err
+	}
+
+	return response, nil
+}
+
+// startSession starts a new session for the user.
+// It returns the access and refresh tokens of the session and an error, if any.
+func (u UserServiceImpl) startSession(ctx context.Context, user dao.User) (dao.TokenResponse, error) {
+	familyId, err := pkg.GenerateRandomToken(16)
+	if err != nil {
+		pkg.Logger(ctx).Error("Error generating session id: ", err)
+		return dao.TokenResponse{}, err
+	}
+
+	refreshToken, next, err := newRefreshToken(user.ID, familyId)
+	if err != nil {
+		return dao.TokenResponse{}, err
+	}
+
+	err = u.refreshTokenRepo.Save(ctx, &next)
+	if err != nil {
+		return dao.TokenResponse{}, err
+	}
+
+	return u.issueTokens(user, familyId, refreshToken)
+}
+
+// rejectLogin counts a failed login.
+// It returns the error to report to the client. Locked accounts get the same error as invalid credentials,
+// so that the response does not reveal which accounts exist or are locked.
+func (u UserServiceImpl) rejectLogin(message string) error {
+	u.metrics.LoginFailed()
+	return pkg.NewUnauthorizedError(message, nil)
+}
+
+// burnPasswordCheck compares the password against dummyPasswordHash and discards the result.
+// Logins rejected without checking the password call it to take as long as a wrong password.
+func burnPasswordCheck(password string) {
+	_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+}
+
+// isLockedOut reports whether the user's account is locked at the given time.
+func isLockedOut(user dao.User, now time.Time) bool {
+	return user.LockedUntil != nil && now.Before(*user.LockedUntil)
+}
+
+// recordFailedLogin counts a failed login of the user, locking the account once loginLockoutThreshold is reached.
+// It returns an error, if any.
+func recordFailedLogin(ctx context.Context, repos repository.Repositories, user dao.User, now time.Time) error {
+	failedLogins := user.FailedLogins + 1
+
+	var lockedUntil *time.Time
+	if failedLogins >= loginLockoutThreshold {
+		until := now.Add(loginLockoutDuration(failedLogins))
+		lockedUntil = &until
+		pkg.Logger(ctx).Warn("Account locked after ", failedLogins, " failed logins until ", until)
+	}
+
+	return repos.User.UpdateLockout(ctx, user.ID, failedLogins, lockedUntil)
+}
+
+// resetFailedLogins clears the failed logins and the lockout of the user after a successful login.
+// It returns an error, if any.
+func resetFailedLogins(ctx context.Context, repos repository.Repositories, user dao.User) error {
+	if user.FailedLogins == 0 && user.LockedUntil == nil {
+		return nil
+	}
+
+	return repos.User.UpdateLockout(ctx, user.ID, 0, nil)
+}
+
+// findOrLinkOidcUser locks the user linked to the provider account of the login within the transaction,
+// linking the account first if needed. Users created for the account get the role by the given ID.
+// It returns the dao.User and an error, if any.
+func findOrLinkOidcUser(ctx context.Context, repos repository.Repositories, login dao.OidcLogin, roleId int) (dao.User, error) {
+	var customErr *pkg.CustomError
+
+	identity, err := repos.UserIdentity.FindByIssuerSubject(ctx, login.Issuer, login.Subject)
+	if err == nil {
+		return repos.User.FindUserByIdForUpdate(ctx, identity.UserID)
+	}
+	if !errors.As(err, &customErr) || customErr.Type != constant.DataNotFound {
+		return dao.User{}, err
+	}
+
+	now := time.Now()
+	user, err := repos.User.FindUserByEmail(ctx, login.Email)
+	switch {
+	case err == nil && user.EmailVerifiedAt != nil:
+		pkg.Logger(ctx).Info("Linking provider account to existing user: ", user.ID)
+	case err == nil:
+		pkg.Logger(ctx).Warn("Linking provider account to unverified user, resetting their password: ", user.ID)
+		user.Password, err = unusablePassword()
+		if err != nil {
+			return dao.User{}, err
+		}
+		user.EmailVerifiedAt = &now
+
+		user, err = repos.User.Save(ctx, &user)
+		if err != nil {
+			return dao.User{}, err
+		}
+
+		err = repos.RefreshToken.RevokeAllByUserId(ctx, user.ID)
+		if err != nil {
+			return dao.User{}, err
+		}
+	case errors.As(err, &customErr) && customErr.Type == constant.DataNotFound:
+		pkg.Logger(ctx).Info("Creating user for provider account")
+		user = dao.User{Email: login.Email, RoleID: roleId, EmailVerifiedAt: &now}
+		user.Password, err = unusablePassword()
+		if err != nil {
+			return dao.User{}, err
+		}
+
+		user, err = repos.User.Save(ctx, &user)
+		if err != nil {
+			return dao.User{}, err
+		}
+	default:
+		return dao.User{}, err
+	}
+
+	err = repos.UserIdentity.Save(ctx, &dao.UserIdentity{
+		UserID:  user.ID,
+		Issuer:  login.Issuer,
+		Subject: login.Subject,
+	})
+	if err != nil {
+		return dao.User{}, err
+	}
+
+	return repos.User.FindUserByIdForUpdate(ctx, user.ID)
+}
+
+// unusablePassword returns the hash of a random password, for users who sign in through an identity provider.
+// They can still set a password of their own with a password reset.
+// It returns the hash and an error, if any.
+func unusablePassword() (string, error) {
+	password, err := pkg.GenerateRandomToken(32)
 	if err != nil {
 		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": foundUser.ID,
-		"email":   foundUser.Email,
-		"role_id": foundUser.RoleID,
-		"exp":     time.Now().Add(time.Hour * 2).Unix(),
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), 14)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// revokeReusedFamily revokes the session of a reused refresh token.
+// It returns the unauthorized error to report to the client, or the revocation error if it fails.
+func (u UserServiceImpl) revokeReusedFamily(ctx context.Context, familyId string) error {
+	err := u.refreshTokenRepo.RevokeFamily(ctx, familyId)
+	if err != nil {
+		return err
+	}
+
+	return pkg.NewUnauthorizedError("Refresh token reuse detected", nil)
+}
+
+// loginLockoutDuration returns how long an account stays locked after the given number of consecutive
+// failed logins, starting at loginLockoutBase and doubling with each failure up to loginLockoutMax.
+func loginLockoutDuration(failedLogins int) time.Duration {
+	duration := loginLockoutBase
+	for i := loginLockoutThreshold; i < failedLogins && duration < loginLockoutMax; i++ {
+		duration *= 2
+	}
+
+	return min(duration, loginLockoutMax)
+}
+
+// newRefreshToken generates a refresh token for the user within the given session.
+// It returns the plain token for the client, the dao.RefreshToken holding its hash, and an error, if any.
+func newRefreshToken(userId int, familyId string) (string, dao.RefreshToken, error) {
+	token, err := pkg.GenerateRandomToken(32)
+	if err != nil {
+		log.Error("Error generating refresh token: ", err)
+		return "", dao.RefreshToken{}, err
+	}
+
+	return token, dao.RefreshToken{
+		UserID:    userId,
+		FamilyID:  familyId,
+		TokenHash: pkg.HashToken(token),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}, nil
+}
+
+// issueTokens signs an access token for the user bound to the given session.
+// It returns the dao.TokenResponse and an error, if any.
+func (u UserServiceImpl) issueTokens(user dao.User, sessionId, refreshToken string) (dao.TokenResponse, error) {
+	accessToken, err := u.tokenSvc.SignToken(jwt.MapClaims{
+		"user_id": user.ID,
+		"email":   user.Email,
+		"role_id": user.RoleID,
+		"sid":     sessionId,
+		"exp":     time.Now().Add(accessTokenTTL).Unix(),
 	})
+	if err != nil {
+		log.Error("Error signing access token: ", err)
+		return dao.TokenResponse{}, err
+	}
+
+	return dao.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+// checkEmailVerified verifies that the user by the given ID has confirmed their email address.
+// It returns an unauthorized error if the email is not verified.
+func checkEmailVerified(ctx context.Context, userRepo repository.UserRepository, userId int) error {
+	user, err := userRepo.FindUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
 
-	return token.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
+	if user.EmailVerifiedAt == nil {
+		pkg.Logger(ctx).Info("Access denied. Email not verified")
+		return pkg.NewUnauthorizedError("Email not verified", nil)
+	}
+
+	return nil
 }
 
-func UserServiceInit(userRepository repository.UserRepository) *UserServiceImpl {
+func UserServiceInit(userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	passwordResetRepository repository.PasswordResetRepository,
+	verificationRepository repository.EmailVerificationRepository,
+	loginAttemptRepository repository.LoginAttemptRepository,
+	mfaChallengeRepository repository.MfaChallengeRepository,
+	txManager repository.TransactionManager,
+	tokenService TokenService,
+	notifier pkg.Notifier,
+	metrics *metrics.Metrics,
+	secretBox *pkg.SecretBox,
+	serverCfg pkg.ServerConfig) *UserServiceImpl {
 	return &UserServiceImpl{
-		userRepo: userRepository,
+		userRepo:          userRepository,
+		roleRepo:          roleRepository,
+		refreshTokenRepo:  refreshTokenRepository,
+		passwordResetRepo: passwordResetRepository,
+		verificationRepo:  verificationRepository,
+		loginAttemptRepo:  loginAttemptRepository,
+		mfaChallengeRepo:  mfaChallengeRepository,
+		txManager:         txManager,
+		tokenSvc:          tokenService,
+		notifier:          notifier,
+		metrics:           metrics,
+		secretBox:         secretBox,
+		baseURL:           strings.TrimSuffix(serverCfg.BaseURL, "/"),
 	}
 }
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey is the statement setting holding the span of the running query.
+const gormSpanKey = "tracing:span"
+
+// gormSpan is a query span together with the statement context it replaced.
+type gormSpan struct {
+	span   trace.Span
+	parent context.Context
+}
+
+// GormPlugin creates a span for each query, as a child of the span in the statement context.
+// The span records the SQL with its placeholders, never the bound values.
+type GormPlugin struct{}
+
+func (p GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", startGormSpan("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", endGormSpan),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", startGormSpan("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", endGormSpan),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", startGormSpan("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", endGormSpan),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", startGormSpan("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", endGormSpan),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", startGormSpan("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", endGormSpan),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", startGormSpan("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", endGormSpan),
+	)
+}
+
+func startGormSpan(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		ctx, span := Tracer().Start(parent, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+
+		db.Statement.Context = ctx
+		db.InstanceSet(gormSpanKey, gormSpan{span: span, parent: parent})
+	}
+}
+
+func endGormSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	current := value.(gormSpan)
+
+	current.span.SetAttributes(
+		semconv.DBSystemNameKey.String(db.Dialector.Name()),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		current.span.RecordError(db.Error)
+		current.span.SetStatus(codes.Error, db.Error.Error())
+	}
+
+	current.span.End()
+	db.Statement.Context = current.parent
+}
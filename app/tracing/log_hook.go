@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogHook adds the trace and span IDs of the entry context to log lines written with a context,
+// e.g. through pkg.Logger, so that they can be correlated with the exported traces.
+type LogHook struct{}
+
+func (h LogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h LogHook) Fire(entry *log.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	entry.Data["trace_id"] = spanContext.TraceID().String()
+	entry.Data["span_id"] = spanContext.SpanID().String()
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"event-booking-api/app/pkg"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName identifies the application in exported traces.
+const ServiceName = "event-booking-api"
+
+// Tracer returns the tracer of the application spans from the global tracer provider.
+// It is looked up on each use rather than stored, since the global provider is replaced
+// whenever the application is initialized.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// TracerProviderInit creates the tracer provider exporting spans as configured and installs it,
+// together with the W3C trace context and baggage propagators, as the global provider.
+// Spans are still created without an exporter, so that log lines carry trace IDs.
+func TracerProviderInit(cfg pkg.TracingConfig) *sdktrace.TracerProvider {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(pkg.Version),
+		)),
+	}
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		log.Fatal("Error creating trace exporter: ", err)
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider
+}
+
+// newExporter returns the span exporter selected by cfg.Exporter, or nil if spans are not exported.
+// Without an endpoint, the OTLP exporter honours the standard OTEL_EXPORTER_OTLP_* variables.
+func newExporter(cfg pkg.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "otlp":
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, nil
+	}
+}
@@ -1,20 +1,24 @@
 package config
 
 import (
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/tracing"
 	"log"
-	"os"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
-func ConnectToDB() *gorm.DB {
-	dsn := os.Getenv("DB_DSN")
-
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
+func ConnectToDB(cfg pkg.DatabaseConfig) *gorm.DB {
+	db, err := gorm.Open(mysql.Open(cfg.DSN), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatal("Error connecting to database. Error: ", err)
 	}
 
+	err = db.Use(tracing.GormPlugin{})
+	if err != nil {
+		log.Fatal("Error registering tracing plugin. Error: ", err)
+	}
+
 	return db
 }
@@ -1,42 +1,129 @@
 package config
 
 import (
+	"context"
+	"errors"
 	"event-booking-api/app/controller"
+	"event-booking-api/app/metrics"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
 	"event-booking-api/app/service"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"gorm.io/gorm"
 )
 
 type Initialization struct {
-	roleRepo     repository.RoleRepository
-	userRepo     repository.UserRepository
-	eventRepo    repository.EventRepository
-	registerRepo repository.RegisterRepository
-	userSvc      service.UserService
-	eventSvc     service.EventService
-	registerSvc  service.RegisterService
-	UserCtrl     controller.UserController
-	EventCtrl    controller.EventController
+	Config         *pkg.Config
+	db             *gorm.DB
+	Metrics        *metrics.Metrics
+	RateLimitStore pkg.RateLimitStore
+	tracerProvider *sdktrace.TracerProvider
+	roleRepo       repository.RoleRepository
+	userRepo       repository.UserRepository
+	eventRepo      repository.EventRepository
+	registerRepo   repository.RegisterRepository
+	refreshRepo    repository.RefreshTokenRepository
+	resetRepo      repository.PasswordResetRepository
+	verifyRepo     repository.EmailVerificationRepository
+	attemptRepo    repository.LoginAttemptRepository
+	challengeRepo  repository.MfaChallengeRepository
+	oidcStateRepo  repository.OidcStateRepository
+	healthRepo     repository.HealthRepository
+	UserSvc        service.UserService
+	mfaSvc         service.MfaService
+	oidcSvc        service.OidcService
+	eventSvc       service.EventService
+	registerSvc    service.RegisterService
+	RoleSvc        service.RoleService
+	TokenSvc       service.TokenService
+	healthSvc      service.HealthService
+	UserCtrl       controller.UserController
+	MfaCtrl        controller.MfaController
+	OidcCtrl       controller.OidcController
+	EventCtrl      controller.EventController
+	RoleCtrl       controller.RoleController
+	KeyCtrl        controller.KeyController
+	HealthCtrl     controller.HealthController
 }
 
-func NewInitialization(roleRepo repository.RoleRepository,
+func NewInitialization(cfg *pkg.Config,
+	db *gorm.DB,
+	metrics *metrics.Metrics,
+	rateLimitStore pkg.RateLimitStore,
+	tracerProvider *sdktrace.TracerProvider,
+	roleRepo repository.RoleRepository,
 	userRepo repository.UserRepository,
 	eventRepo repository.EventRepository,
 	registerRepo repository.RegisterRepository,
+	refreshRepo repository.RefreshTokenRepository,
+	resetRepo repository.PasswordResetRepository,
+	verifyRepo repository.EmailVerificationRepository,
+	attemptRepo repository.LoginAttemptRepository,
+	challengeRepo repository.MfaChallengeRepository,
+	oidcStateRepo repository.OidcStateRepository,
+	healthRepo repository.HealthRepository,
 	userSvc service.UserService,
+	mfaSvc service.MfaService,
+	oidcSvc service.OidcService,
 	eventSvc service.EventService,
 	registerSvc service.RegisterService,
+	roleSvc service.RoleService,
+	tokenSvc service.TokenService,
+	healthSvc service.HealthService,
 	userCtrl controller.UserController,
+	mfaCtrl controller.MfaController,
+	oidcCtrl controller.OidcController,
 	eventCtrl controller.EventController,
+	roleCtrl controller.RoleController,
+	keyCtrl controller.KeyController,
+	healthCtrl controller.HealthController,
 ) *Initialization {
 	return &Initialization{
-		roleRepo:     roleRepo,
-		userRepo:     userRepo,
-		eventRepo:    eventRepo,
-		registerRepo: registerRepo,
-		userSvc:      userSvc,
-		eventSvc:     eventSvc,
-		registerSvc:  registerSvc,
-		UserCtrl:     userCtrl,
-		EventCtrl:    eventCtrl,
+		Config:         cfg,
+		db:             db,
+		Metrics:        metrics,
+		RateLimitStore: rateLimitStore,
+		tracerProvider: tracerProvider,
+		roleRepo:       roleRepo,
+		userRepo:       userRepo,
+		eventRepo:      eventRepo,
+		registerRepo:   registerRepo,
+		refreshRepo:    refreshRepo,
+		resetRepo:      resetRepo,
+		verifyRepo:     verifyRepo,
+		attemptRepo:    attemptRepo,
+		challengeRepo:  challengeRepo,
+		oidcStateRepo:  oidcStateRepo,
+		healthRepo:     healthRepo,
+		UserSvc:        userSvc,
+		mfaSvc:         mfaSvc,
+		oidcSvc:        oidcSvc,
+		eventSvc:       eventSvc,
+		registerSvc:    registerSvc,
+		RoleSvc:        roleSvc,
+		TokenSvc:       tokenSvc,
+		healthSvc:      healthSvc,
+		UserCtrl:       userCtrl,
+		MfaCtrl:        mfaCtrl,
+		OidcCtrl:       oidcCtrl,
+		EventCtrl:      eventCtrl,
+		RoleCtrl:       roleCtrl,
+		KeyCtrl:        keyCtrl,
+		HealthCtrl:     healthCtrl,
+	}
+}
+
+// Close releases the resources held by the application once the server has stopped,
+// flushing the pending spans and closing the database connection pool.
+// It returns an error, if any.
+func (init *Initialization) Close() error {
+	tracingErr := init.tracerProvider.Shutdown(context.Background())
+
+	sqlDB, err := init.db.DB()
+	if err != nil {
+		return errors.Join(tracingErr, err)
 	}
+
+	return errors.Join(tracingErr, sqlDB.Close())
 }
@@ -6,14 +6,29 @@ package config
 
 import (
 	"event-booking-api/app/controller"
+	"event-booking-api/app/metrics"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
 	"event-booking-api/app/service"
+	"event-booking-api/app/tracing"
 
 	"github.com/google/wire"
 )
 
+var cfgFields = wire.FieldsOf(new(*pkg.Config), "Server", "Database", "JWT", "Notifier", "Tracing", "OIDC", "MFA")
+
 var db = wire.NewSet(ConnectToDB)
 
+var notifier = wire.NewSet(pkg.NotifierInit)
+
+var rateLimitStore = wire.NewSet(pkg.RateLimitStoreInit)
+
+var secretBox = wire.NewSet(pkg.SecretBoxInit)
+
+var metricsSet = wire.NewSet(metrics.MetricsInit)
+
+var tracerProviderSet = wire.NewSet(tracing.TracerProviderInit)
+
 var roleRepoSet = wire.NewSet(repository.RoleRepositoryInit,
 	wire.Bind(new(repository.RoleRepository), new(*repository.RoleRepositoryImpl)),
 )
@@ -30,10 +45,50 @@ var registerRepoSet = wire.NewSet(repository.RegisterRepositoryInit,
 	wire.Bind(new(repository.RegisterRepository), new(*repository.RegisterRepositoryImpl)),
 )
 
+var refreshTokenRepoSet = wire.NewSet(repository.RefreshTokenRepositoryInit,
+	wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)),
+)
+
+var passwordResetRepoSet = wire.NewSet(repository.PasswordResetRepositoryInit,
+	wire.Bind(new(repository.PasswordResetRepository), new(*repository.PasswordResetRepositoryImpl)),
+)
+
+var emailVerificationRepoSet = wire.NewSet(repository.EmailVerificationRepositoryInit,
+	wire.Bind(new(repository.EmailVerificationRepository), new(*repository.EmailVerificationRepositoryImpl)),
+)
+
+var loginAttemptRepoSet = wire.NewSet(repository.LoginAttemptRepositoryInit,
+	wire.Bind(new(repository.LoginAttemptRepository), new(*repository.LoginAttemptRepositoryImpl)),
+)
+
+var mfaChallengeRepoSet = wire.NewSet(repository.MfaChallengeRepositoryInit,
+	wire.Bind(new(repository.MfaChallengeRepository), new(*repository.MfaChallengeRepositoryImpl)),
+)
+
+var oidcStateRepoSet = wire.NewSet(repository.OidcStateRepositoryInit,
+	wire.Bind(new(repository.OidcStateRepository), new(*repository.OidcStateRepositoryImpl)),
+)
+
+var txManagerSet = wire.NewSet(repository.TransactionManagerInit,
+	wire.Bind(new(repository.TransactionManager), new(*repository.TransactionManagerImpl)),
+)
+
+var healthRepoSet = wire.NewSet(repository.HealthRepositoryInit,
+	wire.Bind(new(repository.HealthRepository), new(*repository.HealthRepositoryImpl)),
+)
+
 var userSvcSet = wire.NewSet(service.UserServiceInit,
 	wire.Bind(new(service.UserService), new(*service.UserServiceImpl)),
 )
 
+var mfaSvcSet = wire.NewSet(service.MfaServiceInit,
+	wire.Bind(new(service.MfaService), new(*service.MfaServiceImpl)),
+)
+
+var oidcSvcSet = wire.NewSet(service.OidcServiceInit,
+	wire.Bind(new(service.OidcService), new(*service.OidcServiceImpl)),
+)
+
 var eventSvcSet = wire.NewSet(service.EventServiceInit,
 	wire.Bind(new(service.EventService), new(*service.EventServiceImpl)),
 )
@@ -42,27 +97,83 @@ var registerSvcSet = wire.NewSet(service.RegisterServiceInit,
 	wire.Bind(new(service.RegisterService), new(*service.RegisterServiceImpl)),
 )
 
+var roleSvcSet = wire.NewSet(service.RoleServiceInit,
+	wire.Bind(new(service.RoleService), new(*service.RoleServiceImpl)),
+)
+
+var tokenSvcSet = wire.NewSet(service.TokenServiceInit,
+	wire.Bind(new(service.TokenService), new(*service.TokenServiceImpl)),
+)
+
+var healthSvcSet = wire.NewSet(service.HealthServiceInit,
+	wire.Bind(new(service.HealthService), new(*service.HealthServiceImpl)),
+)
+
 var userCtrlSet = wire.NewSet(controller.UserControllerInit,
 	wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)),
 )
 
+var mfaCtrlSet = wire.NewSet(controller.MfaControllerInit,
+	wire.Bind(new(controller.MfaController), new(*controller.MfaControllerImpl)),
+)
+
+var oidcCtrlSet = wire.NewSet(controller.OidcControllerInit,
+	wire.Bind(new(controller.OidcController), new(*controller.OidcControllerImpl)),
+)
+
 var eventCtrlSet = wire.NewSet(controller.EventControllerInit,
 	wire.Bind(new(controller.EventController), new(*controller.EventControllerImpl)),
 )
 
-func Init() *Initialization {
+var roleCtrlSet = wire.NewSet(controller.RoleControllerInit,
+	wire.Bind(new(controller.RoleController), new(*controller.RoleControllerImpl)),
+)
+
+var keyCtrlSet = wire.NewSet(controller.KeyControllerInit,
+	wire.Bind(new(controller.KeyController), new(*controller.KeyControllerImpl)),
+)
+
+var healthCtrlSet = wire.NewSet(controller.HealthControllerInit,
+	wire.Bind(new(controller.HealthController), new(*controller.HealthControllerImpl)),
+)
+
+func Init(cfg *pkg.Config) *Initialization {
 	wire.Build(
 		NewInitialization,
+		cfgFields,
 		db,
+		notifier,
+		rateLimitStore,
+		secretBox,
+		metricsSet,
+		tracerProviderSet,
 		roleRepoSet,
 		userRepoSet,
 		eventRepoSet,
 		registerRepoSet,
+		refreshTokenRepoSet,
+		passwordResetRepoSet,
+		emailVerificationRepoSet,
+		loginAttemptRepoSet,
+		mfaChallengeRepoSet,
+		oidcStateRepoSet,
+		txManagerSet,
+		healthRepoSet,
 		userSvcSet,
+		mfaSvcSet,
+		oidcSvcSet,
 		eventSvcSet,
 		registerSvcSet,
+		roleSvcSet,
+		tokenSvcSet,
+		healthSvcSet,
 		userCtrlSet,
+		mfaCtrlSet,
+		oidcCtrlSet,
 		eventCtrlSet,
+		roleCtrlSet,
+		keyCtrlSet,
+		healthCtrlSet,
 	)
 	return nil
 }
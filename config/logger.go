@@ -1,23 +1,20 @@
 package config
 
 import (
-	"os"
+	"event-booking-api/app/tracing"
+	"time"
 
 	nested "github.com/antonfisher/nested-logrus-formatter"
 	log "github.com/sirupsen/logrus"
 )
 
-func InitLog() {
+func InitLog(level, format string) {
 
-	log.SetLevel(getLoggerLevel(os.Getenv("LOG_LEVEL")))
+	log.SetLevel(getLoggerLevel(level))
 	log.SetReportCaller(true)
-	log.SetFormatter(&nested.Formatter{
-		HideKeys:        true,
-		FieldsOrder:     []string{"component", "category"},
-		TimestampFormat: "2006-01-02 15:04:05",
-		ShowFullLevel:   true,
-		CallerFirst:     true,
-	})
+	log.SetFormatter(getLoggerFormatter(format))
+	log.StandardLogger().ReplaceHooks(log.LevelHooks{})
+	log.AddHook(tracing.LogHook{})
 
 }
 
@@ -33,3 +30,21 @@ func getLoggerLevel(value string) log.Level {
 		return log.WarnLevel
 	}
 }
+
+// getLoggerFormatter returns one JSON object per line for log collectors, or human readable lines otherwise.
+func getLoggerFormatter(value string) log.Formatter {
+	switch value {
+	case "json":
+		return &log.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		}
+	default:
+		return &nested.Formatter{
+			HideKeys:        true,
+			FieldsOrder:     []string{"component", "category", "request_id", "user_id"},
+			TimestampFormat: "2006-01-02 15:04:05",
+			ShowFullLevel:   true,
+			CallerFirst:     true,
+		}
+	}
+}
@@ -8,32 +8,77 @@ package config
 
 import (
 	"event-booking-api/app/controller"
+	"event-booking-api/app/metrics"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/repository"
 	"event-booking-api/app/service"
+	"event-booking-api/app/tracing"
 	"github.com/google/wire"
 )
 
 // Injectors from injector.go:
 
-func Init() *Initialization {
-	gormDB := ConnectToDB()
+func Init(cfg *pkg.Config) *Initialization {
+	databaseConfig := cfg.Database
+	gormDB := ConnectToDB(databaseConfig)
+	metricsMetrics := metrics.MetricsInit(gormDB)
+	pkgRateLimitStore := pkg.RateLimitStoreInit()
+	tracingConfig := cfg.Tracing
+	tracerProvider := tracing.TracerProviderInit(tracingConfig)
 	roleRepositoryImpl := repository.RoleRepositoryInit(gormDB)
 	userRepositoryImpl := repository.UserRepositoryInit(gormDB)
 	eventRepositoryImpl := repository.EventRepositoryInit(gormDB)
 	registerRepositoryImpl := repository.RegisterRepositoryInit(gormDB)
-	userServiceImpl := service.UserServiceInit(userRepositoryImpl)
-	eventServiceImpl := service.EventServiceInit(eventRepositoryImpl)
-	registerServiceImpl := service.RegisterServiceInit(eventRepositoryImpl, registerRepositoryImpl)
+	refreshTokenRepositoryImpl := repository.RefreshTokenRepositoryInit(gormDB)
+	passwordResetRepositoryImpl := repository.PasswordResetRepositoryInit(gormDB)
+	emailVerificationRepositoryImpl := repository.EmailVerificationRepositoryInit(gormDB)
+	loginAttemptRepositoryImpl := repository.LoginAttemptRepositoryInit(gormDB)
+	mfaChallengeRepositoryImpl := repository.MfaChallengeRepositoryInit(gormDB)
+	oidcStateRepositoryImpl := repository.OidcStateRepositoryInit(gormDB)
+	healthRepositoryImpl := repository.HealthRepositoryInit(gormDB)
+	transactionManagerImpl := repository.TransactionManagerInit(gormDB)
+	jwtConfig := cfg.JWT
+	tokenServiceImpl := service.TokenServiceInit(jwtConfig)
+	notifierConfig := cfg.Notifier
+	pkgNotifier := pkg.NotifierInit(notifierConfig)
+	mfaConfig := cfg.MFA
+	pkgSecretBox := pkg.SecretBoxInit(mfaConfig)
+	serverConfig := cfg.Server
+	userServiceImpl := service.UserServiceInit(userRepositoryImpl, roleRepositoryImpl, refreshTokenRepositoryImpl, passwordResetRepositoryImpl, emailVerificationRepositoryImpl, loginAttemptRepositoryImpl, mfaChallengeRepositoryImpl, transactionManagerImpl, tokenServiceImpl, pkgNotifier, metricsMetrics, pkgSecretBox, serverConfig)
+	mfaServiceImpl := service.MfaServiceInit(userRepositoryImpl, roleRepositoryImpl, transactionManagerImpl, pkgSecretBox)
+	oidcConfig := cfg.OIDC
+	oidcServiceImpl := service.OidcServiceInit(oidcConfig, serverConfig, oidcStateRepositoryImpl, userServiceImpl)
+	eventServiceImpl := service.EventServiceInit(eventRepositoryImpl, roleRepositoryImpl, userRepositoryImpl, transactionManagerImpl, metricsMetrics)
+	registerServiceImpl := service.RegisterServiceInit(eventRepositoryImpl, registerRepositoryImpl, roleRepositoryImpl, userRepositoryImpl, transactionManagerImpl, metricsMetrics)
+	roleServiceImpl := service.RoleServiceInit(roleRepositoryImpl)
+	healthServiceImpl := service.HealthServiceInit(healthRepositoryImpl)
 	userControllerImpl := controller.UserControllerInit(userServiceImpl)
+	mfaControllerImpl := controller.MfaControllerInit(mfaServiceImpl)
+	oidcControllerImpl := controller.OidcControllerInit(oidcServiceImpl)
 	eventControllerImpl := controller.EventControllerInit(eventServiceImpl, registerServiceImpl)
-	initialization := NewInitialization(roleRepositoryImpl, userRepositoryImpl, eventRepositoryImpl, registerRepositoryImpl, userServiceImpl, eventServiceImpl, registerServiceImpl, userControllerImpl, eventControllerImpl)
+	roleControllerImpl := controller.RoleControllerInit(roleServiceImpl)
+	keyControllerImpl := controller.KeyControllerInit(tokenServiceImpl)
+	healthControllerImpl := controller.HealthControllerInit(healthServiceImpl)
+	initialization := NewInitialization(cfg, gormDB, metricsMetrics, pkgRateLimitStore, tracerProvider, roleRepositoryImpl, userRepositoryImpl, eventRepositoryImpl, registerRepositoryImpl, refreshTokenRepositoryImpl, passwordResetRepositoryImpl, emailVerificationRepositoryImpl, loginAttemptRepositoryImpl, mfaChallengeRepositoryImpl, oidcStateRepositoryImpl, healthRepositoryImpl, userServiceImpl, mfaServiceImpl, oidcServiceImpl, eventServiceImpl, registerServiceImpl, roleServiceImpl, tokenServiceImpl, healthServiceImpl, userControllerImpl, mfaControllerImpl, oidcControllerImpl, eventControllerImpl, roleControllerImpl, keyControllerImpl, healthControllerImpl)
 	return initialization
 }
 
 // injector.go:
 
+var cfgFields = wire.FieldsOf(new(*pkg.Config), "Server", "Database", "JWT", "Notifier", "Tracing", "OIDC", "MFA")
+
 var db = wire.NewSet(ConnectToDB)
 
+var notifier = wire.NewSet(pkg.NotifierInit)
+
+var rateLimitStore = wire.NewSet(pkg.RateLimitStoreInit)
+
+var secretBox = wire.NewSet(pkg.SecretBoxInit)
+
+var metricsSet = wire.NewSet(metrics.MetricsInit)
+
+var tracerProviderSet = wire.NewSet(tracing.TracerProviderInit)
+
 var roleRepoSet = wire.NewSet(repository.RoleRepositoryInit, wire.Bind(new(repository.RoleRepository), new(*repository.RoleRepositoryImpl)))
 
 var userRepoSet = wire.NewSet(repository.UserRepositoryInit, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)))
@@ -42,12 +87,48 @@ var eventRepoSet = wire.NewSet(repository.EventRepositoryInit, wire.Bind(new(rep
 
 var registerRepoSet = wire.NewSet(repository.RegisterRepositoryInit, wire.Bind(new(repository.RegisterRepository), new(*repository.RegisterRepositoryImpl)))
 
+var refreshTokenRepoSet = wire.NewSet(repository.RefreshTokenRepositoryInit, wire.Bind(new(repository.RefreshTokenRepository), new(*repository.RefreshTokenRepositoryImpl)))
+
+var passwordResetRepoSet = wire.NewSet(repository.PasswordResetRepositoryInit, wire.Bind(new(repository.PasswordResetRepository), new(*repository.PasswordResetRepositoryImpl)))
+
+var emailVerificationRepoSet = wire.NewSet(repository.EmailVerificationRepositoryInit, wire.Bind(new(repository.EmailVerificationRepository), new(*repository.EmailVerificationRepositoryImpl)))
+
+var loginAttemptRepoSet = wire.NewSet(repository.LoginAttemptRepositoryInit, wire.Bind(new(repository.LoginAttemptRepository), new(*repository.LoginAttemptRepositoryImpl)))
+
+var mfaChallengeRepoSet = wire.NewSet(repository.MfaChallengeRepositoryInit, wire.Bind(new(repository.MfaChallengeRepository), new(*repository.MfaChallengeRepositoryImpl)))
+
+var oidcStateRepoSet = wire.NewSet(repository.OidcStateRepositoryInit, wire.Bind(new(repository.OidcStateRepository), new(*repository.OidcStateRepositoryImpl)))
+
+var txManagerSet = wire.NewSet(repository.TransactionManagerInit, wire.Bind(new(repository.TransactionManager), new(*repository.TransactionManagerImpl)))
+
+var healthRepoSet = wire.NewSet(repository.HealthRepositoryInit, wire.Bind(new(repository.HealthRepository), new(*repository.HealthRepositoryImpl)))
+
 var userSvcSet = wire.NewSet(service.UserServiceInit, wire.Bind(new(service.UserService), new(*service.UserServiceImpl)))
 
+var mfaSvcSet = wire.NewSet(service.MfaServiceInit, wire.Bind(new(service.MfaService), new(*service.MfaServiceImpl)))
+
+var oidcSvcSet = wire.NewSet(service.OidcServiceInit, wire.Bind(new(service.OidcService), new(*service.OidcServiceImpl)))
+
 var eventSvcSet = wire.NewSet(service.EventServiceInit, wire.Bind(new(service.EventService), new(*service.EventServiceImpl)))
 
 var registerSvcSet = wire.NewSet(service.RegisterServiceInit, wire.Bind(new(service.RegisterService), new(*service.RegisterServiceImpl)))
 
+var roleSvcSet = wire.NewSet(service.RoleServiceInit, wire.Bind(new(service.RoleService), new(*service.RoleServiceImpl)))
+
+var tokenSvcSet = wire.NewSet(service.TokenServiceInit, wire.Bind(new(service.TokenService), new(*service.TokenServiceImpl)))
+
+var healthSvcSet = wire.NewSet(service.HealthServiceInit, wire.Bind(new(service.HealthService), new(*service.HealthServiceImpl)))
+
 var userCtrlSet = wire.NewSet(controller.UserControllerInit, wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)))
 
+var mfaCtrlSet = wire.NewSet(controller.MfaControllerInit, wire.Bind(new(controller.MfaController), new(*controller.MfaControllerImpl)))
+
+var oidcCtrlSet = wire.NewSet(controller.OidcControllerInit, wire.Bind(new(controller.OidcController), new(*controller.OidcControllerImpl)))
+
 var eventCtrlSet = wire.NewSet(controller.EventControllerInit, wire.Bind(new(controller.EventController), new(*controller.EventControllerImpl)))
+
+var roleCtrlSet = wire.NewSet(controller.RoleControllerInit, wire.Bind(new(controller.RoleController), new(*controller.RoleControllerImpl)))
+
+var keyCtrlSet = wire.NewSet(controller.KeyControllerInit, wire.Bind(new(controller.KeyController), new(*controller.KeyControllerImpl)))
+
+var healthCtrlSet = wire.NewSet(controller.HealthControllerInit, wire.Bind(new(controller.HealthController), new(*controller.HealthControllerImpl)))
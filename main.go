@@ -1,25 +1,21 @@
 package main
 
 import (
+	"context"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/router"
 	"event-booking-api/config"
 	_ "event-booking-api/docs"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func init() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file: ", err)
-	}
-
-	config.InitLog()
-}
-
 //	@title			event-booking-api swagger doc
 //	@version		1.0
 //	@description	event-booking-api swagger doc
@@ -37,14 +33,38 @@ func init() {
 // @in							header
 // @name						Authorization
 func main() {
-	port := os.Getenv("PORT")
+	cfg, err := pkg.LoadConfig()
+	if err != nil {
+		log.Fatal("Error loading configuration:\n", err)
+	}
+
+	config.InitLog(cfg.LogLevel, cfg.LogFormat)
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
 
-	init := config.Init()
+	init := config.Init(cfg)
 	app := router.Init(init)
 
 	app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	if err := app.Run(":" + port); err != nil {
-		log.Fatal("Failed to run server: ", err)
+	listener, err := net.Listen("tcp", ":"+cfg.Server.Port)
+	if err != nil {
+		log.Fatal("Failed to listen: ", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := router.Serve(ctx, router.NewServer(cfg.Server, app), listener, cfg.Server.ShutdownTimeout)
+
+	if err := init.Close(); err != nil {
+		log.Print("Error closing resources: ", err)
+	}
+
+	if serveErr != nil {
+		log.Fatal("Failed to run server: ", serveErr)
 	}
 }
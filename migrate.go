@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"event-booking-api/app/migration"
+	"event-booking-api/app/pkg"
+	"event-booking-api/config"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+const migrateUsage = "Usage: migrate up | down [steps] | status"
+
+// runMigrate runs the migrate subcommand against the configured database.
+//   - up: applies every pending migration.
+//   - down [steps]: reverts the given number of applied migrations, 1 by default.
+//   - status: lists every migration and when it was applied.
+func runMigrate(cfg *pkg.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	db, err := config.ConnectToDB(cfg.Database).DB()
+	if err != nil {
+		log.Fatal("Error getting database connection: ", err)
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Error loading migrations: ", err)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Println("Applied", m)
+		}
+		if err != nil {
+			log.Fatal("Error applying migrations: ", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatal(migrateUsage)
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, m := range reverted {
+			fmt.Println("Reverted", m)
+		}
+		if err != nil {
+			log.Fatal("Error reverting migrations: ", err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal("Error reading migration status: ", err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%-40s %s\n", status.Migration, appliedAt)
+		}
+	default:
+		log.Fatal(migrateUsage)
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"event-booking-api/app/pkg"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestLoadConfig() {
+	tests := []struct {
+		name            string
+		env             map[string]string
+		yaml            string
+		expectedError   string
+		expectedPort    string
+		expectedTimeout time.Duration
+	}{
+		{"SuccessDefaults", nil, "", "", "8080", 10 * time.Second},
+		{"SuccessYamlFile", nil, "server:\n  port: \"9090\"\ndatabase:\n  timeout: 3s\n", "", "9090", 3 * time.Second},
+		{"SuccessEnvOverridesYamlFile", map[string]string{"PORT": "7070"}, "server:\n  port: \"9090\"\n", "", "7070", 10 * time.Second},
+		{"FailureShortSecretKey", map[string]string{"JWT_SECRET_KEY": "supersecret"}, "", "JWT_SECRET_KEY must be at least 32 characters long", "", 0},
+		{"FailureMissingSigningKeys", map[string]string{"JWT_SECRET_KEY": "", "JWT_KEYS_FILE": ""}, "", "JWT_KEYS_FILE or JWT_SECRET_KEY is required", "", 0},
+		{"FailureMissingHS256Deadline", map[string]string{"JWT_HS256_ACCEPT_UNTIL": ""}, "", "JWT_HS256_ACCEPT_UNTIL is required when both JWT_KEYS_FILE and JWT_SECRET_KEY are set", "", 0},
+		{"SuccessKeysFileWithoutSecretKey", map[string]string{"JWT_SECRET_KEY": "", "JWT_HS256_ACCEPT_UNTIL": ""}, "", "", "8080", 10 * time.Second},
+		{"SuccessMissingMfaEncryptionKey", map[string]string{"MFA_ENCRYPTION_KEY": ""}, "", "", "8080", 10 * time.Second},
+		{"FailureMissingDSN", map[string]string{"DB_DSN": ""}, "", "DB_DSN is required", "", 0},
+		{"FailureInvalidTimeout", map[string]string{"DB_TIMEOUT": "soon"}, "", "DB_TIMEOUT", "", 0},
+		{"FailureInvalidPort", map[string]string{"PORT": "http"}, "", "PORT must be a port number", "", 0},
+		{"FailureUnknownLogFormat", map[string]string{"LOG_FORMAT": "xml"}, "", "LOG_FORMAT must be text or json", "", 0},
+		{"FailureUnknownNotifier", map[string]string{"NOTIFIER": "sms"}, "", "NOTIFIER must be log or file", "", 0},
+		{"FailureUnknownTracingExporter", map[string]string{"TRACING_EXPORTER": "jaeger"}, "", "TRACING_EXPORTER must be none, stdout or otlp", "", 0},
+		{"FailureInvalidSampleRatio", map[string]string{"TRACING_SAMPLE_RATIO": "2"}, "", "TRACING_SAMPLE_RATIO must be between 0 and 1", "", 0},
+		{"FailureNegativeRateLimit", map[string]string{"RATE_LIMIT_AUTH_REQUESTS": "-1"}, "", "RATE_LIMIT_AUTH_REQUESTS must not be negative", "", 0},
+		{"FailureInvalidTrustedProxy", map[string]string{"SERVER_TRUSTED_PROXIES": "10.0.0.0/8, proxy"}, "", `"proxy" is not an IP address or CIDR range`, "", 0},
+		{"FailureOidcMissingClientID", map[string]string{"OIDC_ISSUER_URL": "https://idp.example.com"}, "", "OIDC_CLIENT_ID is required for OpenID Connect login", "", 0},
+		{"FailureOidcUnknownRole", map[string]string{"OIDC_ISSUER_URL": "https://idp.example.com", "OIDC_CLIENT_ID": "api", "OIDC_ROLE_MAPPING": "staff=OWNER"}, "", `role of group "staff" must be one of ADMIN, ORGANIZER or USER`, "", 0},
+		{"FailureOidcInvalidRoleMapping", map[string]string{"OIDC_ROLE_MAPPING": "staff"}, "", `"staff" is not a group=ROLE pair`, "", 0},
+		{"FailureMissingYamlFile", map[string]string{"CONFIG_FILE": "missing.yaml"}, "", "reading missing.yaml", "", 0},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			for key, value := range tt.env {
+				previous, ok := os.LookupEnv(key)
+				os.Setenv(key, value)
+				if ok {
+					defer os.Setenv(key, previous)
+				} else {
+					defer os.Unsetenv(key)
+				}
+			}
+
+			if tt.yaml != "" {
+				path := filepath.Join(suite.T().TempDir(), "config.yaml")
+				suite.Require().NoError(os.WriteFile(path, []byte(tt.yaml), 0o600))
+				os.Setenv("CONFIG_FILE", path)
+				defer os.Unsetenv("CONFIG_FILE")
+			}
+
+			cfg, err := pkg.LoadConfig()
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(suite.T(), err, tt.expectedError)
+				return
+			}
+
+			suite.Require().NoError(err)
+			assert.Equal(suite.T(), tt.expectedPort, cfg.Server.Port)
+			assert.Equal(suite.T(), tt.expectedTimeout, cfg.Database.Timeout)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestNewSecretBox() {
+	tests := []struct {
+		name          string
+		key           string
+		expectedError string
+	}{
+		{"Success", testMfaEncryptionKey, ""},
+		{"FailureNotBase64", "not base64!", "key is not base64"},
+		{"FailureShortKey", "c2hvcnQ=", "key must be 32 bytes long, got 5"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := pkg.NewSecretBox(tt.key)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(suite.T(), err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(suite.T(), err)
+		})
+	}
+}
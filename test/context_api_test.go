@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestCancelledRequestAbortsQuery() {
+	tests := []struct {
+		name           string
+		cancel         bool
+		clientTimeout  time.Duration
+		dbTimeout      string
+		lockEvent      bool
+		expectedStatus int
+	}{
+		{"CancelledBeforeQuery", true, 0, "", false, 499},
+		{"ClientDeadlineWhileWaitingForLock", false, 500 * time.Millisecond, "", true, http.StatusGatewayTimeout},
+		{"DBTimeoutWhileWaitingForLock", false, 0, "500ms", true, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			app := suite.app
+			if tt.dbTimeout != "" {
+				os.Setenv("DB_TIMEOUT", tt.dbTimeout)
+				defer os.Unsetenv("DB_TIMEOUT")
+				cfg, err := pkg.LoadConfig()
+				suite.Require().NoError(err)
+				app = router.Init(config.Init(cfg))
+			}
+
+			if tt.lockEvent {
+				tx, err := suite.dbClient.Begin()
+				suite.Require().NoError(err)
+				defer tx.Rollback()
+
+				var id int
+				err = tx.QueryRow("SELECT id FROM events WHERE id = 2 FOR UPDATE").Scan(&id)
+				suite.Require().NoError(err)
+			}
+
+			ctx := context.Background()
+			var cancel context.CancelFunc = func() {}
+			if tt.cancel {
+				ctx, cancel = context.WithCancel(ctx)
+				cancel()
+			} else if tt.clientTimeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, tt.clientTimeout)
+			}
+			defer cancel()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(ctx, "POST", "/api/events/2/register", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+
+			start := time.Now()
+			app.ServeHTTP(w, req)
+
+			assert.Less(suite.T(), time.Since(start), 5*time.Second)
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusGatewayTimeout {
+				assert.Empty(suite.T(), w.Body.Bytes())
+				return
+			}
+
+			var response struct {
+				ResponseKey     string      `json:"response_key"`
+				ResponseMessage string      `json:"response_message"`
+				Data            interface{} `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), "TIMEOUT", response.ResponseKey)
+			assert.Equal(suite.T(), "Request Timeout", response.ResponseMessage)
+		})
+
+		var count int
+		err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = 2 AND user_id = 2").Scan(&count)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), 0, count)
+	}
+}
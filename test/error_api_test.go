@@ -0,0 +1,100 @@
+package test
+
+import (
+	"encoding/json"
+	"event-booking-api/app/domain/dto"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestErrorResponse() {
+	tests := []struct {
+		name            string
+		method          string
+		url             string
+		body            string
+		token           string
+		expectedStatus  int
+		expectedKey     string
+		expectedMessage string
+	}{
+		{"ConflictKeepsMessage", "POST", "/api/users", `{"email": "user1@example.com", "password": "userpass"}`, "", http.StatusConflict, "CONFLICT", "Email already used"},
+		{"NotFoundKeepsMessage", "GET", "/api/events/4", "", "", http.StatusNotFound, "DATA_NOT_FOUND", "Event not found"},
+		{"InvalidRequest", "POST", "/api/users", `{"email": `, "", http.StatusBadRequest, "INVALID_REQUEST", "Invalid request data"},
+		{"UnauthorizedMissingToken", "GET", "/api/users/2", "", "", http.StatusUnauthorized, "UNAUTHORIZED", "Missing bearer token"},
+		{"MessageWithColon", "GET", "/api/roles", "", suite.user1Token, http.StatusUnauthorized, "UNAUTHORIZED", "Missing permission roles:read"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			var response struct {
+				ResponseKey     string      `json:"response_key"`
+				ResponseMessage string      `json:"response_message"`
+				Data            interface{} `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedKey, response.ResponseKey)
+			assert.Equal(suite.T(), tt.expectedMessage, response.ResponseMessage)
+			assert.Nil(suite.T(), response.Data)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestValidationErrorDetails() {
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedErrors []dto.FieldError
+	}{
+		{"AddEventMissingNameAndPastTime", "POST", "/api/events", `{"description": "This is a test event 3", "location": "Tokyo", "event_time": "2024-08-26T12:00:00Z"}`, []dto.FieldError{
+			{Field: "name", Rule: "required", Message: "name is required"},
+			{Field: "event_time", Rule: "future", Message: "event_time must be in the future"},
+		}},
+		{"UpdateEventLocationTooLong", "PUT", "/api/events/1", fmt.Sprintf(`{"location": "%s"}`, strings.Repeat("a", 256)), []dto.FieldError{
+			{Field: "location", Rule: "max", Message: "location must be at most 255 characters long"},
+		}},
+		{"AddUserInvalidEmail", "POST", "/api/users", `{"email": "wrongemail"}`, []dto.FieldError{
+			{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			{Field: "password", Rule: "required", Message: "password is required"},
+		}},
+		{"GetAllEventInvalidSort", "GET", "/api/events?sort=location", "", []dto.FieldError{
+			{Field: "sort", Rule: "oneof", Message: "sort must be one of: event_time, name"},
+		}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+			var response dto.ApiResponse[any]
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), "INVALID_REQUEST", response.ResponseKey)
+			assert.Equal(suite.T(), tt.expectedErrors, response.Errors)
+		})
+	}
+}
@@ -3,10 +3,13 @@ package test
 import (
 	"encoding/json"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -23,12 +26,14 @@ func (suite *ApiTestSuite) TestAddEvent() {
 		expectedStatus int
 		expectedUserId int
 	}{
-		{"SuccessAddEvent", "Test Event 3", "This is a test event 3", "Tokyo", "2024-08-26T12:00:00Z", suite.user1Token, http.StatusCreated, 2},
-		{"FailureMissingEventName", "", "This is a test event 3", "Tokyo", "2024-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
-		{"FailureMissingDescription", "Test Event 3", "", "Tokyo", "2024-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
-		{"FailureMissingLocation", "Test Event 3", "This is a test event 3", "", "2024-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
+		{"SuccessAddEvent", "Test Event 3", "This is a test event 3", "Tokyo", "2030-08-26T12:00:00Z", suite.user1Token, http.StatusCreated, 2},
+		{"FailureMissingEventName", "", "This is a test event 3", "Tokyo", "2030-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
+		{"FailureMissingDescription", "Test Event 3", "", "Tokyo", "2030-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
+		{"FailureMissingLocation", "Test Event 3", "This is a test event 3", "", "2030-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
 		{"FailureMissingEventTime", "Test Event 3", "This is a test event 3", "Tokyo", "", suite.user1Token, http.StatusBadRequest, 0},
-		{"FailureMissingToken", "Test Event 3", "This is a test event 3", "Tokyo", "2024-08-26T12:00:00Z", "", http.StatusUnauthorized, 0},
+		{"FailurePastEventTime", "Test Event 3", "This is a test event 3", "Tokyo", "2024-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
+		{"FailureEventNameTooLong", strings.Repeat("a", 101), "This is a test event 3", "Tokyo", "2030-08-26T12:00:00Z", suite.user1Token, http.StatusBadRequest, 0},
+		{"FailureMissingToken", "Test Event 3", "This is a test event 3", "Tokyo", "2030-08-26T12:00:00Z", "", http.StatusUnauthorized, 0},
 	}
 
 	for _, tt := range tests {
@@ -96,6 +101,168 @@ func (suite *ApiTestSuite) TestGetAllEvent() {
 	}
 }
 
+func (suite *ApiTestSuite) TestGetAllEventWithQuery() {
+	tests := []struct {
+		name              string
+		query             string
+		expectedStatus    int
+		expectedEventIds  []int
+		expectedTotal     int64
+		expectedNextExist bool
+	}{
+		{"SuccessFilterByLocation", "location=Taipei", http.StatusOK, []int{1}, 1, false},
+		{"SuccessFilterByLocationWildcard", "location=%25", http.StatusOK, []int{}, 0, false},
+		{"SuccessFilterByUserId", "user_id=3", http.StatusOK, []int{2}, 1, false},
+		{"SuccessFilterByTimeRange", "from=2024-08-26T00:00:00Z&to=2024-08-27T00:00:00Z", http.StatusOK, []int{1, 2}, 2, false},
+		{"SuccessFilterUpcoming", "upcoming=true", http.StatusOK, []int{}, 0, false},
+		{"SuccessSortByNameDesc", "sort=name&order=desc", http.StatusOK, []int{2, 1}, 2, false},
+		{"SuccessPaginate", "limit=1", http.StatusOK, []int{1}, 2, true},
+		{"SuccessPaginateLastPage", "limit=1&page=2", http.StatusOK, []int{2}, 2, false},
+		{"FailureInvalidSort", "sort=location", http.StatusBadRequest, nil, 0, false},
+		{"FailureInvalidLimit", "limit=1000", http.StatusBadRequest, nil, 0, false},
+		{"FailureInvalidCursor", "cursor=invalid", http.StatusBadRequest, nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/events?"+tt.query, nil)
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string              `json:"response_key"`
+				ResponseMessage string              `json:"response_message"`
+				Data            []dao.EventResponse `json:"data"`
+				Pagination      dto.Pagination      `json:"pagination"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			actualEventIds := make([]int, len(response.Data))
+			for i, event := range response.Data {
+				actualEventIds[i] = event.ID
+			}
+
+			assert.Equal(suite.T(), tt.expectedEventIds, actualEventIds)
+			assert.Equal(suite.T(), tt.expectedTotal, response.Pagination.Total)
+			assert.Equal(suite.T(), tt.expectedNextExist, response.Pagination.Next != "")
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestGetAllEventWithCursor() {
+	var response struct {
+		ResponseKey     string              `json:"response_key"`
+		ResponseMessage string              `json:"response_message"`
+		Data            []dao.EventResponse `json:"data"`
+		Pagination      dto.Pagination      `json:"pagination"`
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/events?limit=1&sort=name", nil)
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(response.Data))
+	assert.Equal(suite.T(), 1, response.Data[0].ID)
+	assert.NotEmpty(suite.T(), response.Pagination.NextCursor)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/events?limit=1&sort=name&cursor="+response.Pagination.NextCursor, nil)
+	suite.app.ServeHTTP(w, req)
+
+	response.Pagination = dto.Pagination{}
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, len(response.Data))
+	assert.Equal(suite.T(), 2, response.Data[0].ID)
+	assert.Empty(suite.T(), response.Pagination.NextCursor)
+
+	cursor := pkg.EncodeCursor("name", "asc", "Test Event 1", 1)
+	mismatches := []struct {
+		name  string
+		query string
+	}{
+		{"FailureCursorOfOtherSort", "sort=event_time"},
+		{"FailureCursorOfOtherOrder", "sort=name&order=desc"},
+	}
+
+	for _, tt := range mismatches {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/events?limit=1&"+tt.query+"&cursor="+cursor, nil)
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestSearchEvent() {
+	_, err := suite.dbClient.Exec(`INSERT INTO events (id, name, description, location, event_time, capacity, user_id)
+		VALUES (3, 'Jazz & Blues <Live>', 'Evening session', 'Tainan', '2024-08-26 12:00:00', 0, 2)`)
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedStatus    int
+		expectedEventIds  []int
+		expectedHighlight map[string]string
+	}{
+		{"SuccessSearchLocation", "q=Taipei", http.StatusOK, []int{1}, map[string]string{"location": "<mark>Taipei</mark>"}},
+		{"SuccessSearchCaseInsensitive", "q=york", http.StatusOK, []int{2}, map[string]string{"location": "New <mark>York</mark>"}},
+		{"SuccessSearchEscapesHtml", "q=blues", http.StatusOK, []int{3}, map[string]string{"name": "Jazz &amp; <mark>Blues</mark> &lt;Live&gt;"}},
+		{"SuccessSearchNoMatch", "q=concert", http.StatusOK, []int{}, nil},
+		{"FailureMissingQuery", "", http.StatusBadRequest, nil, nil},
+		{"FailureBlankQuery", "q=%20%20", http.StatusBadRequest, nil, nil},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/events/search?"+tt.query, nil)
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string                    `json:"response_key"`
+				ResponseMessage string                    `json:"response_message"`
+				Data            []dao.EventSearchResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			actualEventIds := make([]int, len(response.Data))
+			for i, event := range response.Data {
+				actualEventIds[i] = event.ID
+			}
+
+			assert.Equal(suite.T(), tt.expectedEventIds, actualEventIds)
+			if len(response.Data) > 0 {
+				assert.Equal(suite.T(), tt.expectedHighlight, response.Data[0].Highlight)
+				assert.Greater(suite.T(), response.Data[0].Score, 0.0)
+			}
+		})
+	}
+}
+
 func (suite *ApiTestSuite) TestGetEventById() {
 	tests := []struct {
 		name           string
@@ -143,10 +310,11 @@ func (suite *ApiTestSuite) TestUpdateEventById() {
 		token          string
 		expectedStatus int
 	}{
-		{"SuccessUpdateEvent", 1, "Updated Event", "This is updated event", "Updated Location", "2024-08-28T12:00:00Z", suite.user1Token, http.StatusOK},
-		{"FailureMissingToken", 1, "Updated Event", "This is updated event", "Updated Location", "2024-08-28T12:00:00Z", "", http.StatusUnauthorized},
-		{"FailureNotTheEventOwner", 1, "Updated Event", "This is updated event", "Updated Location", "2024-08-28T12:00:00Z", suite.user2Token, http.StatusUnauthorized},
-		{"FailureEventNotFound", 4, "Updated Event", "This is updated event", "Updated Location", "2024-08-28T12:00:00Z", suite.user2Token, http.StatusNotFound},
+		{"SuccessUpdateEvent", 1, "Updated Event", "This is updated event", "Updated Location", "2030-08-28T12:00:00Z", suite.user1Token, http.StatusOK},
+		{"FailureMissingToken", 1, "Updated Event", "This is updated event", "Updated Location", "2030-08-28T12:00:00Z", "", http.StatusUnauthorized},
+		{"FailureNotTheEventOwner", 1, "Updated Event", "This is updated event", "Updated Location", "2030-08-28T12:00:00Z", suite.user2Token, http.StatusUnauthorized},
+		{"FailureEventNotFound", 4, "Updated Event", "This is updated event", "Updated Location", "2030-08-28T12:00:00Z", suite.user2Token, http.StatusNotFound},
+		{"FailureLocationTooLong", 1, "Updated Event", "This is updated event", strings.Repeat("a", 256), "2030-08-28T12:00:00Z", suite.user1Token, http.StatusBadRequest},
 	}
 
 	for _, tt := range tests {
@@ -184,6 +352,152 @@ func (suite *ApiTestSuite) TestUpdateEventById() {
 	}
 }
 
+func (suite *ApiTestSuite) TestUpdateEventCapacity() {
+	// Event 1 has a capacity of 2 with two confirmed registrations; the steps run in order on the same event.
+	tests := []struct {
+		name             string
+		payloads         string
+		expectedStatus   int
+		expectedCapacity int
+	}{
+		{"FailureBelowConfirmed", `{"capacity": 1}`, http.StatusConflict, 2},
+		{"FailureNegativeCapacity", `{"capacity": -1}`, http.StatusBadRequest, 2},
+		{"SuccessUnlimitedCapacity", `{"capacity": 0}`, http.StatusOK, 0},
+		{"SuccessCapacityUnchanged", `{"name": "Renamed Event"}`, http.StatusOK, 0},
+		{"SuccessLimitedCapacity", `{"capacity": 2}`, http.StatusOK, 2},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/events/1", strings.NewReader(tt.payloads))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			var actualCapacity int
+			err := suite.dbClient.QueryRow("SELECT capacity FROM events WHERE id = 1").Scan(&actualCapacity)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedCapacity, actualCapacity)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestUpdateEventCapacityPromotesWaitlist() {
+	_, err := suite.dbClient.Exec("INSERT INTO registers (event_id, user_id, status) VALUES (1, 2, ?)", dao.RegisterStatusWaitlisted)
+	suite.Require().NoError(err)
+	_, err = suite.dbClient.Exec("INSERT INTO users (id, email, password, role_id) VALUES (4, 'user3@example.com', '', 2)")
+	suite.Require().NoError(err)
+	_, err = suite.dbClient.Exec("INSERT INTO registers (event_id, user_id, status) VALUES (1, 4, ?)", dao.RegisterStatusWaitlisted)
+	suite.Require().NoError(err)
+
+	// Event 1 has two confirmed registrations, followed on the waitlist by user 2 and then user 4.
+	tests := []struct {
+		name           string
+		capacity       int
+		expectedStatus map[int]string
+	}{
+		{"SuccessPromoteEarliestWaitlisted", 3, map[int]string{2: dao.RegisterStatusConfirmed, 4: dao.RegisterStatusWaitlisted}},
+		{"SuccessPromoteAllWhenUnlimited", 0, map[int]string{2: dao.RegisterStatusConfirmed, 4: dao.RegisterStatusConfirmed}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/events/1", strings.NewReader(fmt.Sprintf(`{"capacity": %d}`, tt.capacity)))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+			for userId, expectedStatus := range tt.expectedStatus {
+				var actualStatus string
+				err := suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = 1 AND user_id = ?", userId).Scan(&actualStatus)
+				assert.NoError(suite.T(), err)
+
+				assert.Equal(suite.T(), expectedStatus, actualStatus, userId)
+			}
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestUpdateEventConcurrently() {
+	payloads := []string{
+		`{"name": "Concurrent Name"}`,
+		`{"location": "Concurrent Location"}`,
+		`{"description": "Concurrent Description"}`,
+		`{"capacity": 5}`,
+	}
+
+	var wg sync.WaitGroup
+	for _, payload := range payloads {
+		wg.Add(1)
+		go func(payload string) {
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", "/api/events/1", strings.NewReader(payload))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), http.StatusOK, w.Code, payload)
+		}(payload)
+	}
+	wg.Wait()
+
+	// Every update reads the event under its row lock, so none of them writes back a stale copy of the others.
+	var name, location, description string
+	var capacity int
+	err := suite.dbClient.QueryRow("SELECT name, location, description, capacity FROM events WHERE id = 1").
+		Scan(&name, &location, &description, &capacity)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "Concurrent Name", name)
+	assert.Equal(suite.T(), "Concurrent Location", location)
+	assert.Equal(suite.T(), "Concurrent Description", description)
+	assert.Equal(suite.T(), 5, capacity)
+}
+
+func (suite *ApiTestSuite) TestManageEventOfAnotherOrganizer() {
+	// Users 2 and 3 are both organizers, and event 1 belongs to user 2.
+	_, err := suite.dbClient.Exec("UPDATE users SET role_id = 3 WHERE id IN (2, 3)")
+	suite.Require().NoError(err)
+	organizerBToken, _ := suite.generateToken(3, "user2@example.com", 3)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		token          string
+		expectedStatus int
+	}{
+		{"FailureUpdateByOtherOrganizer", "PUT", "/api/events/1", `{"name": "Taken Over"}`, organizerBToken, http.StatusUnauthorized},
+		{"SuccessGetAttendeesByOtherOrganizer", "GET", "/api/events/1/attendees", "", organizerBToken, http.StatusOK},
+		{"SuccessGetWaitlistByOtherOrganizer", "GET", "/api/events/1/register/waitlist", "", organizerBToken, http.StatusOK},
+		{"FailureDeleteByOtherOrganizer", "DELETE", "/api/events/1", "", organizerBToken, http.StatusUnauthorized},
+		{"SuccessUpdateByAdmin", "PUT", "/api/events/1", `{"name": "Renamed By Admin"}`, suite.adminToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	var name string
+	err = suite.dbClient.QueryRow("SELECT name FROM events WHERE id = 1 AND deleted_at IS NULL").Scan(&name)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Renamed By Admin", name)
+}
+
 func (suite *ApiTestSuite) TestDeleteEventById() {
 	tests := []struct {
 		name           string
@@ -217,22 +531,29 @@ func (suite *ApiTestSuite) TestDeleteEventById() {
 			assert.NoError(suite.T(), err)
 
 			assert.Equal(suite.T(), 0, count)
+
+			err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = ? AND deleted_at IS NULL", tt.eventId).Scan(&count)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), 0, count)
 		})
 	}
 }
 
 func (suite *ApiTestSuite) TestRegisterUserForEvent() {
 	tests := []struct {
-		name           string
-		eventId        int
-		token          string
-		expectedStatus int
-		expectedUserId int
+		name                   string
+		eventId                int
+		token                  string
+		expectedStatus         int
+		expectedUserId         int
+		expectedRegisterStatus string
 	}{
-		{"SuccessRegister", 2, suite.user1Token, http.StatusCreated, 2},
-		{"FailureMissingToken", 2, "", http.StatusUnauthorized, 0},
-		{"FailureEventNotFound", 4, suite.user2Token, http.StatusNotFound, 0},
-		{"FailureConflict", 1, suite.user2Token, http.StatusConflict, 0},
+		{"SuccessRegister", 2, suite.user1Token, http.StatusCreated, 2, dao.RegisterStatusConfirmed},
+		{"SuccessWaitlisted", 1, suite.user1Token, http.StatusCreated, 2, dao.RegisterStatusWaitlisted},
+		{"FailureMissingToken", 2, "", http.StatusUnauthorized, 0, ""},
+		{"FailureEventNotFound", 4, suite.user2Token, http.StatusNotFound, 0, ""},
+		{"FailureConflict", 1, suite.user2Token, http.StatusConflict, 0, ""},
 	}
 
 	for _, tt := range tests {
@@ -250,15 +571,96 @@ func (suite *ApiTestSuite) TestRegisterUserForEvent() {
 				return
 			}
 
-			var count int
-			err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = ? AND user_id = ?", tt.eventId, tt.expectedUserId).Scan(&count)
+			var response struct {
+				ResponseKey     string               `json:"response_key"`
+				ResponseMessage string               `json:"response_message"`
+				Data            dao.RegisterResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
 			assert.NoError(suite.T(), err)
 
-			assert.Equal(suite.T(), 1, count)
+			assert.Equal(suite.T(), tt.expectedRegisterStatus, response.Data.Status)
+
+			var actualStatus string
+			err = suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = ? AND user_id = ?", tt.eventId, tt.expectedUserId).Scan(&actualStatus)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedRegisterStatus, actualStatus)
 		})
 	}
 }
 
+func (suite *ApiTestSuite) TestRegisterUserForEventConcurrently() {
+	const capacity = 3
+	const attendees = 10
+
+	result, err := suite.dbClient.Exec("INSERT INTO events (name, description, location, event_time, capacity, user_id) VALUES (?, ?, ?, ?, ?, ?)",
+		"Limited Event", "This is a limited event", "Osaka", "2024-08-26 12:00:00", capacity, 2)
+	assert.NoError(suite.T(), err)
+	eventId, _ := result.LastInsertId()
+
+	tokens := make([]string, attendees)
+	for i := range tokens {
+		email := fmt.Sprintf("attendee%d@example.com", i)
+		result, err := suite.dbClient.Exec("INSERT INTO users (email, password, role_id, email_verified_at) VALUES (?, ?, ?, NOW(3))", email, "password", 2)
+		assert.NoError(suite.T(), err)
+		userId, _ := result.LastInsertId()
+		tokens[i], _ = suite.generateToken(int(userId), email, 2)
+	}
+
+	recorders := make([]*httptest.ResponseRecorder, attendees)
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		wg.Add(1)
+		go func(i int, token string) {
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/events/%v/register", eventId), nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			suite.app.ServeHTTP(w, req)
+
+			recorders[i] = w
+		}(i, token)
+	}
+	wg.Wait()
+
+	var confirmed int
+	positions := make(map[int]bool)
+	for _, w := range recorders {
+		assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+		var response struct {
+			ResponseKey     string               `json:"response_key"`
+			ResponseMessage string               `json:"response_message"`
+			Data            dao.RegisterResponse `json:"data"`
+		}
+
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(suite.T(), err)
+
+		switch response.Data.Status {
+		case dao.RegisterStatusConfirmed:
+			confirmed++
+		case dao.RegisterStatusWaitlisted:
+			positions[response.Data.Position] = true
+		}
+	}
+
+	assert.Equal(suite.T(), capacity, confirmed)
+	assert.Equal(suite.T(), attendees-capacity, len(positions))
+	for position := 1; position <= attendees-capacity; position++ {
+		assert.True(suite.T(), positions[position])
+	}
+
+	var count int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = ? AND status = ?", eventId, dao.RegisterStatusConfirmed).Scan(&count)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), capacity, count)
+}
+
 func (suite *ApiTestSuite) TestUnregisterUserForEvent() {
 	tests := []struct {
 		name           string
@@ -269,6 +671,7 @@ func (suite *ApiTestSuite) TestUnregisterUserForEvent() {
 	}{
 		{"SuccessUnregister", 1, suite.user2Token, http.StatusOK, 3},
 		{"FailureMissingToken", 1, "", http.StatusUnauthorized, 0},
+		{"FailureNotRegistered", 2, suite.user2Token, http.StatusNotFound, 0},
 	}
 
 	for _, tt := range tests {
@@ -286,11 +689,119 @@ func (suite *ApiTestSuite) TestUnregisterUserForEvent() {
 				return
 			}
 
-			var count int
-			err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = ? AND user_id = ? AND deleted_at IS NULL", tt.eventId, tt.expectedUserId).Scan(&count)
+			var actualStatus string
+			err := suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = ? AND user_id = ?", tt.eventId, tt.expectedUserId).Scan(&actualStatus)
 			assert.NoError(suite.T(), err)
 
-			assert.Equal(suite.T(), 0, count)
+			assert.Equal(suite.T(), dao.RegisterStatusCancelled, actualStatus)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestUnregisterUserForEventPromotesWaitlist() {
+	_, err := suite.dbClient.Exec("INSERT INTO registers (event_id, user_id, status) VALUES (?, ?, ?)", 1, 2, dao.RegisterStatusWaitlisted)
+	assert.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/events/1/register", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user2Token))
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var actualStatus string
+	err = suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = ? AND user_id = ?", 1, 2).Scan(&actualStatus)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), dao.RegisterStatusConfirmed, actualStatus)
+}
+
+func (suite *ApiTestSuite) TestGetRegisterById() {
+	tests := []struct {
+		name                   string
+		eventId                int
+		token                  string
+		expectedStatus         int
+		expectedRegisterStatus string
+	}{
+		{"SuccessGetRegister", 1, suite.user2Token, http.StatusOK, dao.RegisterStatusConfirmed},
+		{"FailureMissingToken", 1, "", http.StatusUnauthorized, ""},
+		{"FailureNotRegistered", 1, suite.user1Token, http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/events/%v/register", tt.eventId), nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string               `json:"response_key"`
+				ResponseMessage string               `json:"response_message"`
+				Data            dao.RegisterResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedRegisterStatus, response.Data.Status)
+			assert.Equal(suite.T(), 0, response.Data.Position)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestGetWaitlistById() {
+	_, err := suite.dbClient.Exec("INSERT INTO registers (event_id, user_id, status) VALUES (?, ?, ?)", 1, 2, dao.RegisterStatusWaitlisted)
+	assert.NoError(suite.T(), err)
+
+	tests := []struct {
+		name           string
+		eventId        int
+		token          string
+		expectedStatus int
+	}{
+		{"SuccessGetWaitlist", 1, suite.user1Token, http.StatusOK},
+		{"FailureMissingToken", 1, "", http.StatusUnauthorized},
+		{"FailureNotTheEventOwner", 1, suite.user2Token, http.StatusUnauthorized},
+		{"FailureEventNotFound", 4, suite.user2Token, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/events/%v/register/waitlist", tt.eventId), nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string                 `json:"response_key"`
+				ResponseMessage string                 `json:"response_message"`
+				Data            []dao.RegisterResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), 1, len(response.Data))
+			assert.Equal(suite.T(), 2, response.Data[0].UserID)
+			assert.Equal(suite.T(), 1, response.Data[0].Position)
 		})
 	}
 }
@@ -303,6 +814,7 @@ func (suite *ApiTestSuite) TestGetAttendeesEmailById() {
 		expectedStatus int
 	}{
 		{"SuccessGetAttendeesEmail", 1, suite.user1Token, http.StatusOK},
+		{"SuccessAdminGetAttendeesEmail", 1, suite.adminToken, http.StatusOK},
 		{"FailureMissingToken", 1, "", http.StatusUnauthorized},
 		{"FailureNotTheEventOwner", 1, suite.user2Token, http.StatusUnauthorized},
 		{"FailureEventNotFound", 4, suite.user2Token, http.StatusNotFound},
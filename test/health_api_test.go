@@ -0,0 +1,97 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/migration"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"event-booking-api/config"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestHealthz() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/healthz", nil)
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.Health
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), dto.HealthStatusUp, response.Status)
+	assert.Equal(suite.T(), pkg.Version, response.Version)
+	assert.NotEmpty(suite.T(), response.Commit)
+	assert.Empty(suite.T(), response.Checks)
+}
+
+func (suite *ApiTestSuite) TestReadyz() {
+	tests := []struct {
+		name               string
+		revertMigration    bool
+		closeDatabase      bool
+		expectedStatus     int
+		expectedDatabase   string
+		expectedMigrations string
+	}{
+		{"Success", false, false, http.StatusOK, dto.HealthStatusUp, dto.HealthStatusUp},
+		{"FailurePendingMigration", true, false, http.StatusServiceUnavailable, dto.HealthStatusUp, dto.HealthStatusDown},
+		{"FailureDatabaseClosed", false, true, http.StatusServiceUnavailable, dto.HealthStatusDown, dto.HealthStatusDown},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			app := suite.app
+
+			if tt.revertMigration {
+				migrator, err := migration.NewMigrator(suite.dbClient)
+				suite.Require().NoError(err)
+
+				_, err = migrator.Down(context.Background(), 1)
+				suite.Require().NoError(err)
+				defer migrator.Up(context.Background())
+			}
+
+			if tt.closeDatabase {
+				cfg, err := pkg.LoadConfig()
+				suite.Require().NoError(err)
+				init := config.Init(cfg)
+				suite.Require().NoError(init.Close())
+				app = router.Init(init)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/readyz", nil)
+			app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			var response dto.Health
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(suite.T(), dto.HealthStatusUp, response.Status)
+			} else {
+				assert.Equal(suite.T(), dto.HealthStatusDown, response.Status)
+			}
+			assert.Equal(suite.T(), pkg.Version, response.Version)
+			assert.Equal(suite.T(), tt.expectedDatabase, response.Checks["database"].Status)
+			assert.Equal(suite.T(), tt.expectedMigrations, response.Checks["migrations"].Status)
+
+			if tt.revertMigration {
+				assert.Len(suite.T(), response.Checks["migrations"].Details["pending"], 1)
+			}
+			if tt.closeDatabase {
+				assert.Equal(suite.T(), "database unreachable", response.Checks["database"].Error)
+				assert.Equal(suite.T(), "migration status unavailable", response.Checks["migrations"].Error)
+			}
+		})
+	}
+}
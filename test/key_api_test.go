@@ -0,0 +1,88 @@
+package test
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"event-booking-api/app/domain/dto"
+	"event-booking-api/app/pkg"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestGetJWKS() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var response dto.JWKS
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+
+	algs := make(map[string]string)
+	for _, key := range response.Keys {
+		algs[key.Kid] = key.Alg
+	}
+
+	assert.Equal(suite.T(), map[string]string{"rsa-previous": "RS256", "ed-current": "EdDSA", "ed-next": "EdDSA"}, algs)
+}
+
+func (suite *ApiTestSuite) TestLoginSignsWithActiveKey() {
+	session := suite.login("user1@example.com", "userpass")
+
+	token, _, err := jwt.NewParser().ParseUnverified(session.AccessToken, jwt.MapClaims{})
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "ed-current", token.Header["kid"])
+	assert.Equal(suite.T(), "EdDSA", token.Header["alg"])
+}
+
+func (suite *ApiTestSuite) TestAuthWithSigningKeys() {
+	sessionId, _ := pkg.GenerateRandomToken(16)
+	refreshToken, _ := pkg.GenerateRandomToken(32)
+	_, err := suite.dbClient.Exec("INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (?, ?, ?, ?)",
+		2, sessionId, pkg.HashToken(refreshToken), time.Now().Add(time.Hour*24))
+	assert.NoError(suite.T(), err)
+
+	sign := func(kid string, key *rsa.PrivateKey) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"user_id": 2,
+			"email":   "user1@example.com",
+			"role_id": 2,
+			"sid":     sessionId,
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, _ := token.SignedString(key)
+		return signed
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"SuccessPreviousKey", sign("rsa-previous", suite.rsaKey), http.StatusOK},
+		{"SuccessHS256DuringMigration", suite.user1Token, http.StatusOK},
+		{"FailureRetiredKey", sign("rsa-retired", suite.retiredKey), http.StatusUnauthorized},
+		{"FailureUnknownKid", sign("unknown", suite.rsaKey), http.StatusUnauthorized},
+		{"FailureKidKeyMismatch", sign("rsa-previous", suite.retiredKey), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/users/2", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestRequestLogging() {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		requestId      string
+		expectedStatus int
+		expectedRoute  string
+		expectedUserId interface{}
+		expectedEchoed bool
+	}{
+		{"SuccessIncomingRequestId", "GET", "/api/users/2", suite.user1Token, "req-123", http.StatusOK, "/api/users/:userId", float64(2), true},
+		{"SuccessGeneratedRequestId", "GET", "/api/events/1", "", "", http.StatusOK, "/api/events/:eventId", nil, false},
+		{"SuccessMalformedRequestIdReplaced", "GET", "/api/events/1", "", "bad id\nlevel=error", http.StatusOK, "/api/events/:eventId", nil, false},
+		{"FailureUnauthorized", "GET", "/api/users/2", "", "req-456", http.StatusUnauthorized, "/api/users/:userId", nil, true},
+		{"FailureUnmatchedRoute", "GET", "/api/unknown", "", "", http.StatusNotFound, "unmatched", nil, false},
+	}
+
+	defer config.InitLog("DEBUG", "text")
+	defer log.SetOutput(os.Stderr)
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			var logs bytes.Buffer
+			config.InitLog("DEBUG", "json")
+			log.SetOutput(&logs)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			if tt.requestId != "" {
+				req.Header.Set("X-Request-ID", tt.requestId)
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			requestId := w.Header().Get("X-Request-ID")
+			assert.NotEmpty(suite.T(), requestId)
+			if tt.expectedEchoed {
+				assert.Equal(suite.T(), tt.requestId, requestId)
+			} else {
+				assert.NotEqual(suite.T(), tt.requestId, requestId)
+			}
+
+			var entries []map[string]interface{}
+			scanner := bufio.NewScanner(&logs)
+			for scanner.Scan() {
+				var entry map[string]interface{}
+				suite.Require().NoError(json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+				entries = append(entries, entry)
+			}
+			suite.Require().NotEmpty(entries)
+
+			var serviceUserIds []interface{}
+			for _, entry := range entries {
+				assert.Equal(suite.T(), requestId, entry["request_id"], entry["msg"])
+				if entry["msg"] != "Request handled" {
+					serviceUserIds = append(serviceUserIds, entry["user_id"])
+				}
+			}
+			if tt.expectedUserId != nil {
+				assert.Contains(suite.T(), serviceUserIds, tt.expectedUserId)
+			}
+
+			access := entries[len(entries)-1]
+			assert.Equal(suite.T(), "Request handled", access["msg"])
+			assert.Equal(suite.T(), tt.method, access["method"])
+			assert.Equal(suite.T(), tt.expectedRoute, access["route"])
+			assert.Equal(suite.T(), float64(tt.expectedStatus), access["status"])
+			assert.Contains(suite.T(), access, "latency_ms")
+			assert.Equal(suite.T(), tt.expectedUserId, access["user_id"])
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestAccessLogAtDefaultLevel() {
+	var logs bytes.Buffer
+	config.InitLog("WARN", "json")
+	log.SetOutput(&logs)
+	defer config.InitLog("DEBUG", "text")
+	defer log.SetOutput(os.Stderr)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/events/1", nil)
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(&logs)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		suite.Require().NoError(json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+		entries = append(entries, entry)
+	}
+
+	suite.Require().Len(entries, 1)
+	assert.Equal(suite.T(), "Request handled", entries[0]["msg"])
+	assert.Equal(suite.T(), "info", entries[0]["level"])
+	assert.Equal(suite.T(), float64(http.StatusOK), entries[0]["status"])
+}
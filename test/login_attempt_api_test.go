@@ -0,0 +1,190 @@
+package test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"event-booking-api/app/domain/dao"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestLoginLockout() {
+	tests := []struct {
+		name                 string
+		password             string
+		lockExpired          bool
+		expectedStatus       int
+		expectedFailedLogins int
+		expectedLockSeconds  int64
+	}{
+		{"FailureFirstWrongPassword", "wrongpass", false, http.StatusUnauthorized, 1, 0},
+		{"FailureSecondWrongPassword", "wrongpass", false, http.StatusUnauthorized, 2, 0},
+		{"FailureThirdWrongPassword", "wrongpass", false, http.StatusUnauthorized, 3, 0},
+		{"FailureFourthWrongPassword", "wrongpass", false, http.StatusUnauthorized, 4, 0},
+		{"FailureFifthWrongPasswordLocksAccount", "wrongpass", false, http.StatusUnauthorized, 5, 60},
+		{"FailureValidPasswordWhileLocked", "userpass", false, http.StatusUnauthorized, 5, 60},
+		{"FailureWrongPasswordDoublesLock", "wrongpass", true, http.StatusUnauthorized, 6, 120},
+		{"SuccessValidPasswordResetsLock", "userpass", true, http.StatusOK, 0, 0},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			if tt.lockExpired {
+				_, err := suite.dbClient.Exec("UPDATE users SET locked_until = '2000-01-01 00:00:00' WHERE id = 2")
+				suite.Require().NoError(err)
+			}
+
+			loginCredentials := fmt.Sprintf(`{"email": "user1@example.com", "password": "%s"}`, tt.password)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(loginCredentials))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			var failedLogins int
+			var lockSeconds sql.NullInt64
+			err := suite.dbClient.QueryRow(`SELECT failed_logins,
+				TIMESTAMPDIFF(SECOND, (SELECT MAX(created_at) FROM login_attempts WHERE user_id = 2), locked_until)
+				FROM users WHERE id = 2`).Scan(&failedLogins, &lockSeconds)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedFailedLogins, failedLogins)
+			if tt.expectedLockSeconds == 0 {
+				assert.False(suite.T(), lockSeconds.Valid)
+				return
+			}
+
+			assert.InDelta(suite.T(), tt.expectedLockSeconds, lockSeconds.Int64, 5)
+		})
+	}
+
+	var attempts, successes int
+	err := suite.dbClient.QueryRow("SELECT COUNT(*), SUM(success) FROM login_attempts WHERE user_id = 2").Scan(&attempts, &successes)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), len(tests), attempts)
+	assert.Equal(suite.T(), 1, successes)
+}
+
+func (suite *ApiTestSuite) TestLoginLockedAccountLikeUnknownEmail() {
+	_, err := suite.dbClient.Exec("UPDATE users SET failed_logins = 5, locked_until = '2999-01-01 00:00:00' WHERE id = 2")
+	suite.Require().NoError(err)
+
+	login := func(email string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(fmt.Sprintf(`{"email": "%s", "password": "userpass"}`, email)))
+		suite.app.ServeHTTP(w, req)
+		return w
+	}
+
+	locked := login("user1@example.com")
+	unknown := login("nobody@example.com")
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, locked.Code)
+	assert.Equal(suite.T(), unknown.Code, locked.Code)
+	assert.JSONEq(suite.T(), unknown.Body.String(), locked.Body.String())
+}
+
+func (suite *ApiTestSuite) TestUnlockUserById() {
+	_, err := suite.dbClient.Exec("UPDATE users SET failed_logins = 5, locked_until = '2999-01-01 00:00:00' WHERE id = 2")
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name           string
+		userId         int
+		token          string
+		expectedStatus int
+	}{
+		{"FailureMissingToken", 2, "", http.StatusUnauthorized},
+		{"FailureNotTheAdmin", 2, suite.user1Token, http.StatusUnauthorized},
+		{"FailureUserNotFound", 99, suite.adminToken, http.StatusNotFound},
+		{"SuccessUnlockUser", 2, suite.adminToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/api/users/%v/unlock", tt.userId), nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var failedLogins int
+			var lockedUntil sql.NullTime
+			err := suite.dbClient.QueryRow("SELECT failed_logins, locked_until FROM users WHERE id = ?", tt.userId).Scan(&failedLogins, &lockedUntil)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), 0, failedLogins)
+			assert.False(suite.T(), lockedUntil.Valid)
+
+			suite.login("user1@example.com", "userpass")
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestGetLoginHistoryById() {
+	for _, password := range []string{"wrongpass", "userpass"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(fmt.Sprintf(`{"email": "user1@example.com", "password": "%s"}`, password)))
+		req.RemoteAddr = "203.0.113.7:41000"
+		suite.app.ServeHTTP(w, req)
+	}
+
+	tests := []struct {
+		name            string
+		userId          int
+		token           string
+		expectedStatus  int
+		expectedSuccess []bool
+	}{
+		{"SuccessOwnHistory", 2, suite.user1Token, http.StatusOK, []bool{true, false}},
+		{"SuccessEmptyHistory", 3, suite.user2Token, http.StatusOK, []bool{}},
+		{"FailureNotTheOwner", 2, suite.user2Token, http.StatusUnauthorized, nil},
+		{"FailureMissingToken", 2, "", http.StatusUnauthorized, nil},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/users/%v/logins", tt.userId), nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data []dao.LoginAttempt `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			actualSuccess := make([]bool, 0, len(response.Data))
+			for _, attempt := range response.Data {
+				actualSuccess = append(actualSuccess, attempt.Success)
+				assert.Equal(suite.T(), "203.0.113.7", attempt.IPAddress)
+				assert.NotZero(suite.T(), attempt.CreatedAt)
+			}
+
+			assert.Equal(suite.T(), tt.expectedSuccess, actualSuccess)
+		})
+	}
+}
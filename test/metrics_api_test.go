@@ -0,0 +1,66 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestMetrics() {
+	requests := []struct {
+		method         string
+		path           string
+		body           string
+		token          string
+		expectedStatus int
+	}{
+		{"POST", "/api/events", `{"name": "Test Event 3", "description": "This is a test event 3", "location": "Tokyo", "event_time": "2030-08-26T12:00:00Z"}`, suite.user1Token, http.StatusCreated},
+		{"POST", "/api/events/1/register", "", suite.user1Token, http.StatusCreated},
+		{"GET", "/api/events/1/register", "", suite.user1Token, http.StatusOK},
+		{"DELETE", "/api/events/1/register", "", suite.user2Token, http.StatusOK},
+		{"POST", "/api/users/login", `{"email": "user1@example.com", "password": "wrongpass"}`, "", http.StatusUnauthorized},
+		{"GET", "/api/events/1", "", "", http.StatusOK},
+		{"GET", "/api/events/2", "", "", http.StatusOK},
+		{"GET", "/api/unknown", "", "", http.StatusNotFound},
+	}
+
+	for _, r := range requests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(r.method, r.path, strings.NewReader(r.body))
+		if r.token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
+		}
+		suite.app.ServeHTTP(w, req)
+		suite.Require().Equal(r.expectedStatus, w.Code, r.path)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"RequestsByRouteTemplate", `event_booking_http_requests_total{method="GET",route="/api/events/:eventId",status="200"} 2`},
+		{"RequestsUnmatchedRoute", `event_booking_http_requests_total{method="GET",route="unmatched",status="404"} 1`},
+		{"RequestsErrorStatus", `event_booking_http_requests_total{method="POST",route="/api/users/login",status="401"} 1`},
+		{"RequestDuration", `event_booking_http_request_duration_seconds_count{method="POST",route="/api/events",status="201"} 1`},
+		{"DatabasePool", `go_sql_max_open_connections{db_name="event_booking"}`},
+		{"EventsCreated", `event_booking_events_created_total 1`},
+		{"RegistrationsCreated", `event_booking_registrations_created_total{status="waitlisted"} 1`},
+		{"RegistrationsCancelled", `event_booking_registrations_cancelled_total 1`},
+		{"FailedLogins", `event_booking_failed_logins_total 1`},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Contains(suite.T(), w.Body.String(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package test
+
+import (
+	"encoding/json"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMfaSecret = "JBSWY3DPEHPK3PXP"
+
+func (suite *ApiTestSuite) TestMfaEnrollment() {
+	var secret, lastCode string
+	var recoveryCodes, retiredCodes []string
+	totpCode := func(offset time.Duration) func() string {
+		return func() string {
+			lastCode, _ = totp.GenerateCode(secret, time.Now().Add(offset))
+			return lastCode
+		}
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		code           func() string
+		expectedStatus int
+	}{
+		{"FailureActivateBeforeEnroll", "/api/users/mfa/activate", func() string { return "123456" }, http.StatusBadRequest},
+		{"SuccessEnroll", "/api/users/mfa/enroll", nil, http.StatusOK},
+		{"FailureActivateWrongCode", "/api/users/mfa/activate", totpCode(-time.Hour), http.StatusUnauthorized},
+		{"SuccessActivate", "/api/users/mfa/activate", totpCode(0), http.StatusOK},
+		{"FailureEnrollAgain", "/api/users/mfa/enroll", nil, http.StatusConflict},
+		{"FailureReplayedCode", "/api/users/mfa/recovery-codes", func() string { return lastCode }, http.StatusUnauthorized},
+		{"SuccessRegenerateWithRecoveryCode", "/api/users/mfa/recovery-codes", func() string { return recoveryCodes[0] }, http.StatusOK},
+		{"FailureDisableWithReplacedRecoveryCode", "/api/users/mfa/disable", func() string { return retiredCodes[1] }, http.StatusUnauthorized},
+		{"SuccessDisable", "/api/users/mfa/disable", totpCode(30 * time.Second), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			var body *strings.Reader
+			if tt.code != nil {
+				body = strings.NewReader(fmt.Sprintf(`{"code": "%s"}`, tt.code()))
+			} else {
+				body = strings.NewReader("")
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", tt.path, body)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data struct {
+					dao.MfaEnrollmentResponse
+					dao.RecoveryCodesResponse
+				} `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			var storedSecret string
+			var enabled bool
+			err = suite.dbClient.QueryRow("SELECT mfa_secret, mfa_enabled_at IS NOT NULL FROM users WHERE id = 2").Scan(&storedSecret, &enabled)
+			assert.NoError(suite.T(), err)
+
+			switch tt.path {
+			case "/api/users/mfa/enroll":
+				secret = response.Data.Secret
+				assert.NotEmpty(suite.T(), secret)
+				assert.NotContains(suite.T(), storedSecret, secret)
+				assert.Equal(suite.T(), secret, suite.openMfaSecret(storedSecret))
+				assert.True(suite.T(), strings.HasPrefix(response.Data.ProvisioningURI, "otpauth://totp/"))
+				assert.Contains(suite.T(), response.Data.ProvisioningURI, "secret="+secret)
+				assert.False(suite.T(), enabled)
+			case "/api/users/mfa/disable":
+				assert.Empty(suite.T(), storedSecret)
+				assert.False(suite.T(), enabled)
+
+				var remaining int
+				err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM recovery_codes WHERE user_id = 2 AND deleted_at IS NULL").Scan(&remaining)
+				assert.NoError(suite.T(), err)
+				assert.Equal(suite.T(), 0, remaining)
+			default:
+				assert.NotEqual(suite.T(), recoveryCodes, response.Data.RecoveryCodes)
+				retiredCodes, recoveryCodes = recoveryCodes, response.Data.RecoveryCodes
+				assert.Len(suite.T(), recoveryCodes, 10)
+				assert.True(suite.T(), enabled)
+			}
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestVerifyMfaLogin() {
+	_, err := suite.dbClient.Exec("UPDATE users SET mfa_secret = ?, mfa_enabled_at = NOW(3) WHERE id = 2", suite.sealMfaSecret(testMfaSecret))
+	suite.Require().NoError(err)
+	_, err = suite.dbClient.Exec("INSERT INTO recovery_codes (user_id, code_hash) VALUES (2, ?)", pkg.HashToken("abcdefgh"))
+	suite.Require().NoError(err)
+
+	var usedMfaToken, usedCode string
+	currentCode := func() string {
+		usedCode, _ = totp.GenerateCode(testMfaSecret, time.Now())
+		return usedCode
+	}
+
+	tests := []struct {
+		name           string
+		mfaToken       func() string
+		code           func() string
+		expectedStatus int
+	}{
+		{"FailureWrongCode", suite.startMfaLogin, func() string {
+			code, _ := totp.GenerateCode(testMfaSecret, time.Now().Add(-time.Hour))
+			return code
+		}, http.StatusUnauthorized},
+		{"FailureInvalidMfaToken", func() string { return "invalid" }, currentCode, http.StatusUnauthorized},
+		{"FailureMissingCode", suite.startMfaLogin, func() string { return "" }, http.StatusBadRequest},
+		{"SuccessTotpCode", suite.startMfaLogin, currentCode, http.StatusOK},
+		{"FailureReplayedTotpCode", suite.startMfaLogin, func() string { return usedCode }, http.StatusUnauthorized},
+		{"FailureUsedMfaToken", func() string { return usedMfaToken }, func() string { return "abcd-efgh" }, http.StatusUnauthorized},
+		{"SuccessRecoveryCode", suite.startMfaLogin, func() string { return "ABCD-EFGH" }, http.StatusOK},
+		{"FailureUsedRecoveryCode", suite.startMfaLogin, func() string { return "abcd-efgh" }, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			mfaToken := tt.mfaToken()
+			payloads := fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, mfaToken, tt.code())
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/login/mfa", strings.NewReader(payloads))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			usedMfaToken = mfaToken
+
+			var response struct {
+				Data dao.LoginResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			suite.Require().NotNil(response.Data.TokenResponse)
+			assert.NotEmpty(suite.T(), response.Data.AccessToken)
+			assert.NotEmpty(suite.T(), response.Data.RefreshToken)
+			assert.Empty(suite.T(), response.Data.RecoveryCodes)
+		})
+	}
+
+	var attempts, successes int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*), SUM(success) FROM login_attempts WHERE user_id = 2").Scan(&attempts, &successes)
+	assert.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), 5, attempts)
+	assert.Equal(suite.T(), 2, successes)
+}
+
+func (suite *ApiTestSuite) TestVerifyMfaLoginLockedAccountLikeWrongCode() {
+	_, err := suite.dbClient.Exec("UPDATE users SET mfa_secret = ?, mfa_enabled_at = NOW(3) WHERE id = 2", suite.sealMfaSecret(testMfaSecret))
+	suite.Require().NoError(err)
+
+	verify := func(mfaToken, code string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/login/mfa", strings.NewReader(fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, mfaToken, code)))
+		suite.app.ServeHTTP(w, req)
+		return w
+	}
+
+	mfaToken := suite.startMfaLogin()
+	wrongCode, _ := totp.GenerateCode(testMfaSecret, time.Now().Add(-time.Hour))
+	wrong := verify(mfaToken, wrongCode)
+
+	_, err = suite.dbClient.Exec("UPDATE users SET failed_logins = 5, locked_until = '2999-01-01 00:00:00' WHERE id = 2")
+	suite.Require().NoError(err)
+
+	validCode, _ := totp.GenerateCode(testMfaSecret, time.Now())
+	locked := verify(mfaToken, validCode)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, locked.Code)
+	assert.Equal(suite.T(), wrong.Code, locked.Code)
+	assert.JSONEq(suite.T(), wrong.Body.String(), locked.Body.String())
+}
+
+func (suite *ApiTestSuite) TestVerifyMfaLoginWithPlainSecret() {
+	// Secrets stored before they were encrypted at rest are still in plain.
+	_, err := suite.dbClient.Exec("UPDATE users SET mfa_secret = ?, mfa_enabled_at = NOW(3) WHERE id = 2", testMfaSecret)
+	suite.Require().NoError(err)
+
+	code, _ := totp.GenerateCode(testMfaSecret, time.Now())
+	payloads := fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, suite.startMfaLogin(), code)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users/login/mfa", strings.NewReader(payloads))
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var secret string
+	var enabled bool
+	err = suite.dbClient.QueryRow("SELECT mfa_secret, mfa_enabled_at IS NOT NULL FROM users WHERE id = 2").Scan(&secret, &enabled)
+	suite.Require().NoError(err)
+
+	assert.NotEqual(suite.T(), testMfaSecret, secret)
+	assert.Equal(suite.T(), testMfaSecret, suite.openMfaSecret(secret))
+	assert.True(suite.T(), enabled)
+}
+
+func (suite *ApiTestSuite) TestUpdateRoleMfaById() {
+	tests := []struct {
+		name           string
+		roleId         int
+		payloads       string
+		token          string
+		expectedStatus int
+	}{
+		{"FailureNotTheAdmin", 2, `{"required": true}`, suite.user1Token, http.StatusUnauthorized},
+		{"FailureMissingRequired", 2, `{}`, suite.adminToken, http.StatusBadRequest},
+		{"FailureRoleNotFound", 99, `{"required": true}`, suite.adminToken, http.StatusNotFound},
+		{"SuccessRequireMfa", 2, `{"required": true}`, suite.adminToken, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/roles/%v/mfa", tt.roleId), strings.NewReader(tt.payloads))
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var required bool
+			err := suite.dbClient.QueryRow("SELECT mfa_required FROM roles WHERE id = ?", tt.roleId).Scan(&required)
+			assert.NoError(suite.T(), err)
+
+			assert.True(suite.T(), required)
+		})
+	}
+
+	suite.Run("SuccessLoginEnrollsUser", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(`{"email": "user2@example.com", "password": "userpass"}`))
+		suite.app.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var challenge struct {
+			Data dao.LoginResponse `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &challenge))
+
+		assert.True(suite.T(), challenge.Data.MfaRequired)
+		assert.Nil(suite.T(), challenge.Data.TokenResponse)
+		suite.Require().NotNil(challenge.Data.MfaEnrollment)
+
+		code, _ := totp.GenerateCode(challenge.Data.MfaEnrollment.Secret, time.Now())
+		payloads := fmt.Sprintf(`{"mfa_token": "%s", "code": "%s"}`, challenge.Data.MfaToken, code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("POST", "/api/users/login/mfa", strings.NewReader(payloads))
+		suite.app.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var response struct {
+			Data dao.LoginResponse `json:"data"`
+		}
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+		suite.Require().NotNil(response.Data.TokenResponse)
+		assert.NotEmpty(suite.T(), response.Data.AccessToken)
+		assert.Len(suite.T(), response.Data.RecoveryCodes, 10)
+
+		var enabled bool
+		err := suite.dbClient.QueryRow("SELECT mfa_enabled_at IS NOT NULL FROM users WHERE id = 3").Scan(&enabled)
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), enabled)
+	})
+
+	suite.Run("FailureDisableRequiredMfa", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/mfa/disable", strings.NewReader(`{"code": "abcd-efgh"}`))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user2Token))
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	})
+}
+
+// startMfaLogin signs user1 in with their password and returns the MFA token of the login.
+func (suite *ApiTestSuite) startMfaLogin() string {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(`{"email": "user1@example.com", "password": "userpass"}`))
+	suite.app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Data dao.LoginResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Require().True(response.Data.MfaRequired)
+
+	return response.Data.MfaToken
+}
+
+// sealMfaSecret encrypts the TOTP secret as the application stores it.
+func (suite *ApiTestSuite) sealMfaSecret(secret string) string {
+	box, err := pkg.NewSecretBox(testMfaEncryptionKey)
+	suite.Require().NoError(err)
+
+	sealed, err := box.Seal(secret)
+	suite.Require().NoError(err)
+
+	return sealed
+}
+
+// openMfaSecret decrypts a TOTP secret stored by the application.
+func (suite *ApiTestSuite) openMfaSecret(sealed string) string {
+	box, err := pkg.NewSecretBox(testMfaEncryptionKey)
+	suite.Require().NoError(err)
+
+	secret, err := box.Open(sealed)
+	suite.Require().NoError(err)
+
+	return secret
+}
@@ -0,0 +1,190 @@
+package test
+
+import (
+	"context"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/migration"
+	"os"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+func (suite *ApiTestSuite) TestMigrationStatus() {
+	migrator, err := migration.NewMigrator(suite.dbClient)
+	suite.Require().NoError(err)
+
+	statuses, err := migrator.Status(context.Background())
+	suite.Require().NoError(err)
+
+	assert.NotEmpty(suite.T(), statuses)
+	for i, status := range statuses {
+		assert.NotNil(suite.T(), status.AppliedAt, status.Migration.String())
+		if i > 0 {
+			assert.Greater(suite.T(), status.Version, statuses[i-1].Version)
+		}
+	}
+}
+
+func (suite *ApiTestSuite) TestMigrationDownAndUp() {
+	ctx := context.Background()
+
+	migrator, err := migration.NewMigrator(suite.dbClient)
+	suite.Require().NoError(err)
+
+	statuses, err := migrator.Status(ctx)
+	suite.Require().NoError(err)
+
+	reverted, err := migrator.Down(ctx, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(reverted, 1)
+	assert.Equal(suite.T(), statuses[len(statuses)-1].Version, reverted[0].Version)
+
+	reverted, err = migrator.Down(ctx, len(statuses))
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), reverted, len(statuses)-1)
+
+	var tables int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name <> 'schema_migrations'").Scan(&tables)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, tables)
+
+	applied, err := migrator.Up(ctx)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), applied, len(statuses))
+
+	applied, err = migrator.Up(ctx)
+	suite.Require().NoError(err)
+	assert.Empty(suite.T(), applied)
+
+	var roles int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM roles").Scan(&roles)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, roles)
+}
+
+func (suite *ApiTestSuite) TestMigrationDownKeepsEncryptedMfaSecrets() {
+	ctx := context.Background()
+
+	_, err := suite.dbClient.Exec("UPDATE users SET mfa_secret = ?, mfa_enabled_at = NOW(3) WHERE id = 2", suite.sealMfaSecret(testMfaSecret))
+	suite.Require().NoError(err)
+
+	migrator, err := migration.NewMigrator(suite.dbClient)
+	suite.Require().NoError(err)
+
+	statuses, err := migrator.Status(ctx)
+	suite.Require().NoError(err)
+
+	steps := 0
+	for _, status := range statuses {
+		if status.Version >= 17 {
+			steps++
+		}
+	}
+
+	_, err = migrator.Down(ctx, steps)
+	assert.ErrorContains(suite.T(), err, "000017_encrypt_mfa_secrets")
+
+	var secretLength int
+	var enabled bool
+	err = suite.dbClient.QueryRow("SELECT CHAR_LENGTH(mfa_secret), mfa_enabled_at IS NOT NULL FROM users WHERE id = 2").Scan(&secretLength, &enabled)
+	assert.NoError(suite.T(), err)
+	assert.Greater(suite.T(), secretLength, 64)
+	assert.True(suite.T(), enabled)
+}
+
+func (suite *ApiTestSuite) TestMigrationUpFromBaselineDump() {
+	ctx := context.Background()
+
+	migrator, err := migration.NewMigrator(suite.dbClient)
+	suite.Require().NoError(err)
+
+	statuses, err := migrator.Status(ctx)
+	suite.Require().NoError(err)
+
+	_, err = migrator.Down(ctx, len(statuses))
+	suite.Require().NoError(err)
+	_, err = suite.dbClient.Exec("DROP TABLE schema_migrations")
+	suite.Require().NoError(err)
+
+	// The dump turns off foreign key checks for its session, so it is loaded on a single connection.
+	dump, err := os.ReadFile("baseline.sql")
+	suite.Require().NoError(err)
+
+	conn, err := suite.dbClient.Conn(ctx)
+	suite.Require().NoError(err)
+	for _, statement := range migration.SplitStatements(string(dump)) {
+		_, err = conn.ExecContext(ctx, statement)
+		suite.Require().NoError(err, statement)
+	}
+	suite.Require().NoError(conn.Close())
+
+	for _, statement := range []string{
+		"INSERT INTO users (id, email, password, role_id, created_at) VALUES (1, 'legacy@example.com', 'hash', 2, '2024-08-28 10:00:00')",
+		"INSERT INTO events (id, name, description, location, event_time, user_id) VALUES (1, 'Legacy Event', 'Before migrations', 'Taipei', '2024-09-01 12:00:00', 1)",
+		"INSERT INTO registers (id, event_id, user_id) VALUES (1, 1, 1)",
+	} {
+		_, err = suite.dbClient.Exec(statement)
+		suite.Require().NoError(err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	suite.Require().NoError(err)
+	assert.Len(suite.T(), applied, len(statuses))
+
+	suite.assertSchemaMatchesModels()
+
+	var capacity int
+	var status string
+	err = suite.dbClient.QueryRow("SELECT e.capacity, r.status FROM events e JOIN registers r ON r.event_id = e.id WHERE e.id = 1").Scan(&capacity, &status)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, capacity)
+	assert.Equal(suite.T(), "confirmed", status)
+
+	var verified bool
+	err = suite.dbClient.QueryRow("SELECT email_verified_at = created_at FROM users WHERE id = 1").Scan(&verified)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), verified)
+
+	var fulltextIndexes int
+	err = suite.dbClient.QueryRow(`SELECT COUNT(DISTINCT index_name) FROM information_schema.statistics
+		WHERE table_schema = DATABASE() AND table_name = 'events' AND index_type = 'FULLTEXT'`).Scan(&fulltextIndexes)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, fulltextIndexes)
+
+	var roles int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM roles").Scan(&roles)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, roles)
+}
+
+func (suite *ApiTestSuite) TestMigrationsMatchModels() {
+	suite.assertSchemaMatchesModels()
+}
+
+// assertSchemaMatchesModels checks that the migrated database has a table for every model and a column for every field.
+func (suite *ApiTestSuite) assertSchemaMatchesModels() {
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: suite.dbClient}), &gorm.Config{})
+	suite.Require().NoError(err)
+
+	models := []interface{}{
+		&dao.Role{}, &dao.Permission{}, &dao.User{}, &dao.Event{}, &dao.Register{},
+		&dao.RefreshToken{}, &dao.PasswordReset{}, &dao.EmailVerification{}, &dao.LoginAttempt{},
+		&dao.MfaChallenge{}, &dao.RecoveryCode{}, &dao.UserIdentity{}, &dao.OidcState{},
+	}
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		suite.Require().NoError(stmt.Parse(model))
+
+		suite.Run(stmt.Schema.Table, func() {
+			assert.True(suite.T(), db.Migrator().HasTable(model))
+			for _, field := range stmt.Schema.Fields {
+				if field.DBName != "" {
+					assert.True(suite.T(), db.Migrator().HasColumn(model, field.DBName), field.DBName)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"encoding/json"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestOidcLogin() {
+	provider := newOidcStubProvider()
+	defer provider.close()
+
+	env := map[string]string{
+		"OIDC_ISSUER_URL":    provider.server.URL,
+		"OIDC_CLIENT_ID":     oidcStubClientID,
+		"OIDC_CLIENT_SECRET": "stub-secret",
+		"OIDC_ROLE_MAPPING":  "it-admins=ADMIN,event-organizers=ORGANIZER",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	cfg, err := pkg.LoadConfig()
+	suite.Require().NoError(err)
+	app := router.Init(config.Init(cfg))
+
+	// The steps share one provider, so later steps sign in the accounts linked by earlier ones.
+	steps := []struct {
+		name           string
+		claims         map[string]interface{}
+		expectedStatus int
+		expectedEmail  string
+		expectedRoleId int
+	}{
+		{"FailureUnverifiedEmail", map[string]interface{}{"sub": "staff-1", "email": "staff@example.com", "email_verified": false}, http.StatusUnauthorized, "", 0},
+		{"SuccessCreateUser", map[string]interface{}{"sub": "staff-1", "email": "Staff@example.com", "email_verified": true, "groups": []string{"event-organizers"}}, http.StatusOK, "staff@example.com", 3},
+		{"SuccessLinkByEmail", map[string]interface{}{"sub": "staff-2", "email": "user1@example.com", "email_verified": "true"}, http.StatusOK, "user1@example.com", 2},
+		{"SuccessLinkedBySubject", map[string]interface{}{"sub": "staff-1", "email": "renamed@example.com", "email_verified": true, "groups": []string{"event-organizers", "it-admins"}}, http.StatusOK, "staff@example.com", 1},
+		{"SuccessUnmappedGroupGetsUser", map[string]interface{}{"sub": "staff-1", "email": "staff@example.com", "email_verified": true, "groups": "contractors"}, http.StatusOK, "staff@example.com", 2},
+	}
+
+	for _, tt := range steps {
+		suite.Run(tt.name, func() {
+			provider.signIn(tt.claims)
+			w := suite.oidcLogin(app, provider)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data dao.LoginResponse `json:"data"`
+			}
+			suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+			suite.Require().NotNil(response.Data.TokenResponse)
+			assert.NotEmpty(suite.T(), response.Data.AccessToken)
+			assert.NotEmpty(suite.T(), response.Data.RefreshToken)
+
+			var email string
+			var roleId int
+			var verified bool
+			err := suite.dbClient.QueryRow(`SELECT u.email, u.role_id, u.email_verified_at IS NOT NULL FROM users u
+				JOIN user_identities i ON i.user_id = u.id WHERE i.issuer = ? AND i.subject = ?`,
+				provider.server.URL, tt.claims["sub"]).Scan(&email, &roleId, &verified)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedEmail, email)
+			assert.Equal(suite.T(), tt.expectedRoleId, roleId)
+			assert.True(suite.T(), verified)
+		})
+	}
+
+	suite.Run("FailureUnverifiedUserSessionsRevoked", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(`{"email": "user1@example.com", "password": "userpass"}`))
+		app.ServeHTTP(w, req)
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/api/users/2", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+		app.ServeHTTP(w, req)
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	})
+
+	suite.Run("FailureReplayedCallback", func() {
+		provider.signIn(map[string]interface{}{"sub": "staff-1", "email": "staff@example.com", "email_verified": true})
+		callback := suite.oidcCallbackURL(app, provider)
+
+		for _, expectedStatus := range []int{http.StatusOK, http.StatusUnauthorized} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", callback, nil)
+			app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), expectedStatus, w.Code)
+		}
+	})
+
+	callbackTests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"FailureUnknownState", "code=abc&state=unknown", http.StatusUnauthorized},
+		{"FailureMissingCode", "state=unknown", http.StatusBadRequest},
+		{"FailureProviderError", "error=access_denied&state=unknown", http.StatusUnauthorized},
+	}
+
+	for _, tt := range callbackTests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/users/oidc/callback?"+tt.query, nil)
+			app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	suite.Run("FailureLockedAccount", func() {
+		_, err := suite.dbClient.Exec("UPDATE users SET failed_logins = 5, locked_until = '2999-01-01 00:00:00' WHERE email = 'staff@example.com'")
+		suite.Require().NoError(err)
+
+		provider.signIn(map[string]interface{}{"sub": "staff-1", "email": "staff@example.com", "email_verified": true})
+		w := suite.oidcLogin(app, provider)
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	})
+
+	var successes int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM login_attempts WHERE success AND user_id IN (SELECT user_id FROM user_identities)").Scan(&successes)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, successes)
+}
+
+func (suite *ApiTestSuite) TestOidcLoginNotConfigured() {
+	for _, path := range []string{"/api/users/oidc/login", "/api/users/oidc/callback?code=abc&state=abc"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusNotFound, w.Code, path)
+	}
+}
+
+// oidcLogin signs in through the stub provider and returns the response of the callback.
+func (suite *ApiTestSuite) oidcLogin(app *gin.Engine, provider *oidcStubProvider) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", suite.oidcCallbackURL(app, provider), nil)
+	app.ServeHTTP(w, req)
+
+	return w
+}
+
+// oidcCallbackURL starts a login and follows the redirect to the stub provider.
+// It returns the path and query of the callback the provider redirects back to.
+func (suite *ApiTestSuite) oidcCallbackURL(app *gin.Engine, provider *oidcStubProvider) string {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/users/oidc/login", nil)
+	app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusFound, w.Code)
+
+	client := provider.server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := client.Get(w.Header().Get("Location"))
+	suite.Require().NoError(err)
+	resp.Body.Close()
+	suite.Require().Equal(http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	suite.Require().NoError(err)
+	suite.Require().Equal("/api/users/oidc/callback", callback.Path)
+
+	return callback.RequestURI()
+}
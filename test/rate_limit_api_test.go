@@ -0,0 +1,87 @@
+package test
+
+import (
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestRateLimit() {
+	env := map[string]string{
+		"RATE_LIMIT_AUTH_REQUESTS":    "2",
+		"RATE_LIMIT_AUTH_PERIOD":      "1h",
+		"RATE_LIMIT_BOOKING_REQUESTS": "1",
+		"RATE_LIMIT_BOOKING_PERIOD":   "1h",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	cfg, err := pkg.LoadConfig()
+	suite.Require().NoError(err)
+	app := router.Init(config.Init(cfg))
+
+	login := `{"email": "user1@example.com", "password": "wrongpass"}`
+
+	// The steps share one application, so each one sees the requests of the previous steps.
+	steps := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		token          string
+		remoteAddr     string
+		forwardedFor   string
+		expectedStatus int
+	}{
+		{"SuccessFirstLogin", "POST", "/api/users/login", login, "", "192.0.2.1:1234", "", http.StatusUnauthorized},
+		{"SuccessSecondLogin", "POST", "/api/users/login", login, "", "192.0.2.1:1234", "", http.StatusUnauthorized},
+		{"FailureLoginLimitExceeded", "POST", "/api/users/login", login, "", "192.0.2.1:1234", "", http.StatusTooManyRequests},
+		{"FailureGroupSharedAcrossRoutes", "POST", "/api/users/password/reset", `{"email": "user1@example.com"}`, "", "192.0.2.1:1234", "", http.StatusTooManyRequests},
+		{"FailureForwardedForIgnored", "POST", "/api/users/login", login, "", "192.0.2.1:1234", "198.51.100.7", http.StatusTooManyRequests},
+		{"SuccessOtherIP", "POST", "/api/users/login", login, "", "198.51.100.7:1234", "", http.StatusUnauthorized},
+		{"SuccessFirstBooking", "POST", "/api/events/2/register", "", suite.user1Token, "192.0.2.1:1234", "", http.StatusCreated},
+		{"FailureBookingLimitExceeded", "DELETE", "/api/events/2/register", "", suite.user1Token, "192.0.2.1:1234", "", http.StatusTooManyRequests},
+		{"SuccessOtherUser", "DELETE", "/api/events/1/register", "", suite.user2Token, "192.0.2.1:1234", "", http.StatusOK},
+		{"SuccessNotLimited", "GET", "/api/events/2/register", "", suite.user1Token, "192.0.2.1:1234", "", http.StatusOK},
+	}
+
+	for _, tt := range steps {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.RemoteAddr = tt.remoteAddr
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusTooManyRequests {
+				retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+				assert.NoError(suite.T(), err)
+				assert.Positive(suite.T(), retryAfter)
+			} else {
+				assert.Empty(suite.T(), w.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	var status string
+	err = suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = 2 AND user_id = 2").Scan(&status)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "confirmed", status)
+}
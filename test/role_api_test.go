@@ -0,0 +1,63 @@
+package test
+
+import (
+	"encoding/json"
+	"event-booking-api/app/constant"
+	"event-booking-api/app/domain/dao"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestGetAllRole() {
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"SuccessGetAllRole", suite.adminToken, http.StatusOK},
+		{"FailureMissingToken", "", http.StatusUnauthorized},
+		{"FailureNotTheAdmin", suite.user1Token, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/roles", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string             `json:"response_key"`
+				ResponseMessage string             `json:"response_message"`
+				Data            []dao.RoleResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			roles := make(map[string][]string)
+			for _, role := range response.Data {
+				roles[role.Role] = role.Permissions
+			}
+
+			assert.Equal(suite.T(), 3, len(roles))
+			assert.Contains(suite.T(), roles[constant.RoleAdmin], constant.PermissionAssignRole)
+			assert.Contains(suite.T(), roles[constant.RoleAdmin], constant.PermissionManageEvent)
+			assert.NotContains(suite.T(), roles[constant.RoleOrganizer], constant.PermissionManageEvent)
+			assert.Contains(suite.T(), roles[constant.RoleOrganizer], constant.PermissionReadAttendees)
+			assert.NotContains(suite.T(), roles[constant.RoleUser], constant.PermissionReadAttendees)
+			assert.NotContains(suite.T(), roles[constant.RoleUser], constant.PermissionManageEvent)
+		})
+	}
+}
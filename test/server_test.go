@@ -0,0 +1,94 @@
+package test
+
+import (
+	"context"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ApiTestSuite) TestGracefulShutdown() {
+	tests := []struct {
+		name            string
+		shutdownTimeout time.Duration
+		lockHeldFor     time.Duration
+		expectedStatus  int
+		expectedErr     bool
+		expectedCount   int
+	}{
+		{"SuccessDrainsInFlightRequest", 5 * time.Second, 500 * time.Millisecond, http.StatusCreated, false, 1},
+		{"FailureShutdownTimeoutExceeded", 200 * time.Millisecond, 2 * time.Second, 0, true, 0},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tx, err := suite.dbClient.Begin()
+			suite.Require().NoError(err)
+			defer tx.Rollback()
+
+			var id int
+			err = tx.QueryRow("SELECT id FROM events WHERE id = 2 FOR UPDATE").Scan(&id)
+			suite.Require().NoError(err)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			suite.Require().NoError(err)
+
+			srv := router.NewServer(pkg.ServerConfig{
+				ReadTimeout:       5 * time.Second,
+				ReadHeaderTimeout: 5 * time.Second,
+				WriteTimeout:      30 * time.Second,
+				IdleTimeout:       5 * time.Second,
+			}, suite.app)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- router.Serve(ctx, srv, listener, tt.shutdownTimeout)
+			}()
+
+			responseStatus := make(chan int, 1)
+			go func() {
+				req, _ := http.NewRequest("POST", fmt.Sprintf("http://%s/api/events/2/register", listener.Addr()), nil)
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					responseStatus <- 0
+					return
+				}
+				resp.Body.Close()
+				responseStatus <- resp.StatusCode
+			}()
+
+			// Let the request reach the locked event row before shutting down.
+			time.Sleep(300 * time.Millisecond)
+			cancel()
+
+			time.Sleep(100 * time.Millisecond)
+			_, err = net.DialTimeout("tcp", listener.Addr().String(), time.Second)
+			assert.Error(suite.T(), err)
+
+			time.Sleep(tt.lockHeldFor)
+			suite.Require().NoError(tx.Rollback())
+
+			assert.Equal(suite.T(), tt.expectedStatus, <-responseStatus)
+			if tt.expectedErr {
+				assert.Error(suite.T(), <-serveErr)
+			} else {
+				assert.NoError(suite.T(), <-serveErr)
+			}
+
+			var count int
+			err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE event_id = 2 AND user_id = 2 AND deleted_at IS NULL").Scan(&count)
+			assert.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.expectedCount, count)
+		})
+	}
+}
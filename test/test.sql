@@ -1,151 +1,7 @@
--- MySQL dump 10.13  Distrib 8.0.38, for macos14 (arm64)
---
--- Host: localhost    Database: testdb
--- ------------------------------------------------------
--- Server version	8.0.37
+-- Seed data for the API test suite, loaded after the migrations have been applied.
 
-/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
-/*!40101 SET @OLD_CHARACTER_SET_RESULTS=@@CHARACTER_SET_RESULTS */;
-/*!40101 SET @OLD_COLLATION_CONNECTION=@@COLLATION_CONNECTION */;
-/*!50503 SET NAMES utf8 */;
-/*!40103 SET @OLD_TIME_ZONE=@@TIME_ZONE */;
-/*!40103 SET TIME_ZONE='+00:00' */;
-/*!40014 SET @OLD_UNIQUE_CHECKS=@@UNIQUE_CHECKS, UNIQUE_CHECKS=0 */;
-/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;
-/*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
-/*!40111 SET @OLD_SQL_NOTES=@@SQL_NOTES, SQL_NOTES=0 */;
+INSERT INTO `users` VALUES (1,'admin@example.com','$2a$14$VLIDdiRsX3G52vWnZRsTXufc.h2yWTUNeDmX.uez8edFG4lacu4m.',1,'2024-08-28 10:05:00.900','2024-08-28 10:05:00.900',NULL,NULL,0,NULL,'',NULL,0),(2,'user1@example.com','$2a$14$Rg//Wd2N2GyJJNQWJ.JY9eJpgdEw7dtUIPiRooRleMkbPsn1.1hIK',2,'2024-08-28 10:05:40.348','2024-08-28 10:05:40.348',NULL,NULL,0,NULL,'',NULL,0),(3,'user2@example.com','$2a$14$h0CbczVla3LkkFF7IkmpzOgtSUC398NySeDD5diPl19FOfxL0CFA.',2,'2024-08-28 10:05:52.061','2024-08-28 10:05:52.061',NULL,NULL,0,NULL,'',NULL,0);
 
---
--- Table structure for table `events`
---
+INSERT INTO `events` VALUES (1,'Test Event 1','This is a test event','Taipei','2024-08-26 12:00:00.000',2,2,'2024-08-28 11:00:37.900',NULL,NULL),(2,'Test Event 2','This is a test event','New York','2024-08-26 12:00:00.000',0,3,'2024-08-28 11:01:56.275',NULL,NULL);
 
-DROP TABLE IF EXISTS `events`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `events` (
-  `id` bigint NOT NULL AUTO_INCREMENT,
-  `name` longtext NOT NULL,
-  `description` longtext NOT NULL,
-  `location` longtext NOT NULL,
-  `event_time` datetime(3) NOT NULL,
-  `user_id` bigint NOT NULL,
-  `created_at` datetime(3) DEFAULT NULL,
-  `updated_at` datetime(3) DEFAULT NULL,
-  `deleted_at` datetime(3) DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  KEY `fk_events_user` (`user_id`),
-  CONSTRAINT `fk_events_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `events`
---
-
-LOCK TABLES `events` WRITE;
-/*!40000 ALTER TABLE `events` DISABLE KEYS */;
-INSERT INTO `events` VALUES (1,'Test Event 1','This is a test event','Taipei','2024-08-26 12:00:00.000',2,'2024-08-28 11:00:37.900',NULL,NULL),(2,'Test Event 2','This is a test event','New York','2024-08-26 12:00:00.000',3,'2024-08-28 11:01:56.275',NULL,NULL);
-/*!40000 ALTER TABLE `events` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `registers`
---
-
-DROP TABLE IF EXISTS `registers`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `registers` (
-  `id` bigint NOT NULL AUTO_INCREMENT,
-  `event_id` bigint NOT NULL,
-  `user_id` bigint NOT NULL,
-  `created_at` datetime(3) DEFAULT NULL,
-  `updated_at` datetime(3) DEFAULT NULL,
-  `deleted_at` datetime(3) DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  UNIQUE KEY `idx_event_user` (`event_id`,`user_id`),
-  KEY `fk_registers_user` (`user_id`),
-  CONSTRAINT `fk_registers_event` FOREIGN KEY (`event_id`) REFERENCES `events` (`id`),
-  CONSTRAINT `fk_registers_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `registers`
---
-
-LOCK TABLES `registers` WRITE;
-/*!40000 ALTER TABLE `registers` DISABLE KEYS */;
-INSERT INTO `registers` VALUES (1,1,3,'2024-08-28 11:05:49.418',NULL,NULL),(2,1,1,'2024-08-28 11:06:57.543',NULL,NULL);
-/*!40000 ALTER TABLE `registers` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `roles`
---
-
-DROP TABLE IF EXISTS `roles`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `roles` (
-  `id` bigint NOT NULL AUTO_INCREMENT,
-  `role` longtext NOT NULL,
-  `created_at` datetime(3) DEFAULT NULL,
-  `updated_at` datetime(3) DEFAULT NULL,
-  `deleted_at` datetime(3) DEFAULT NULL,
-  PRIMARY KEY (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=3 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `roles`
---
-
-LOCK TABLES `roles` WRITE;
-/*!40000 ALTER TABLE `roles` DISABLE KEYS */;
-INSERT INTO `roles` VALUES (1,'ADMIN',NULL,NULL,NULL),(2,'USER',NULL,NULL,NULL);
-/*!40000 ALTER TABLE `roles` ENABLE KEYS */;
-UNLOCK TABLES;
-
---
--- Table structure for table `users`
---
-
-DROP TABLE IF EXISTS `users`;
-/*!40101 SET @saved_cs_client     = @@character_set_client */;
-/*!50503 SET character_set_client = utf8mb4 */;
-CREATE TABLE `users` (
-  `id` bigint NOT NULL AUTO_INCREMENT,
-  `email` varchar(255) NOT NULL,
-  `password` longtext NOT NULL,
-  `role_id` bigint NOT NULL,
-  `created_at` datetime(3) DEFAULT NULL,
-  `updated_at` datetime(3) DEFAULT NULL,
-  `deleted_at` datetime(3) DEFAULT NULL,
-  PRIMARY KEY (`id`),
-  UNIQUE KEY `idx_users_email` (`email`),
-  KEY `fk_users_role` (`role_id`),
-  CONSTRAINT `fk_users_role` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
-) ENGINE=InnoDB AUTO_INCREMENT=4 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
-/*!40101 SET character_set_client = @saved_cs_client */;
-
---
--- Dumping data for table `users`
---
-
-LOCK TABLES `users` WRITE;
-/*!40000 ALTER TABLE `users` DISABLE KEYS */;
-INSERT INTO `users` VALUES (1,'admin@example.com','$2a$14$VLIDdiRsX3G52vWnZRsTXufc.h2yWTUNeDmX.uez8edFG4lacu4m.',1,'2024-08-28 10:05:00.900',NULL,NULL),(2,'user1@example.com','$2a$14$Rg//Wd2N2GyJJNQWJ.JY9eJpgdEw7dtUIPiRooRleMkbPsn1.1hIK',2,'2024-08-28 10:05:40.348',NULL,NULL),(3,'user2@example.com','$2a$14$h0CbczVla3LkkFF7IkmpzOgtSUC398NySeDD5diPl19FOfxL0CFA.',2,'2024-08-28 10:05:52.061',NULL,NULL);
-/*!40000 ALTER TABLE `users` ENABLE KEYS */;
-UNLOCK TABLES;
-/*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
-
-/*!40101 SET SQL_MODE=@OLD_SQL_MODE */;
-/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;
-/*!40014 SET UNIQUE_CHECKS=@OLD_UNIQUE_CHECKS */;
-/*!40101 SET CHARACTER_SET_CLIENT=@OLD_CHARACTER_SET_CLIENT */;
-/*!40101 SET CHARACTER_SET_RESULTS=@OLD_CHARACTER_SET_RESULTS */;
-/*!40101 SET COLLATION_CONNECTION=@OLD_COLLATION_CONNECTION */;
-/*!40111 SET SQL_NOTES=@OLD_SQL_NOTES */;
-
--- Dump completed on 2024-08-28 19:15:32
+INSERT INTO `registers` VALUES (1,1,3,'confirmed','2024-08-28 11:05:49.418',NULL,NULL),(2,1,1,'confirmed','2024-08-28 11:06:57.543',NULL,NULL);
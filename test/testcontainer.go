@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"database/sql"
+	"event-booking-api/app/migration"
 	"log"
 	"os"
 	"path/filepath"
@@ -20,7 +21,6 @@ func setupMysqlContainer() (string, *sql.DB, func()) {
 		mysql.WithDatabase("testdb"),
 		mysql.WithUsername("testuser"),
 		mysql.WithPassword("testpass"),
-		mysql.WithScripts(filepath.Join(dirPath, "test.sql")),
 	)
 	if err != nil {
 		log.Fatalf("failed to start mysql container: %s", err)
@@ -44,5 +44,35 @@ func setupMysqlContainer() (string, *sql.DB, func()) {
 		log.Fatalf("could not connect to mysql: %s", err)
 	}
 
+	if err := migrateAndSeed(ctx, client, filepath.Join(dirPath, "test.sql")); err != nil {
+		terminate()
+		log.Fatalf("could not prepare mysql schema: %s", err)
+	}
+
 	return dsn, client, terminate
 }
+
+// migrateAndSeed applies the migrations to the database and loads the seed data script.
+func migrateAndSeed(ctx context.Context, client *sql.DB, seedPath string) error {
+	migrator, err := migration.NewMigrator(client)
+	if err != nil {
+		return err
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		return err
+	}
+
+	seed, err := os.ReadFile(seedPath)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range migration.SplitStatements(string(seed)) {
+		if _, err := client.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,10 +1,25 @@
 package test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
 	"event-booking-api/app/router"
 	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +27,9 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
+// testMfaEncryptionKey is the key the TOTP secrets are encrypted with during the tests.
+const testMfaEncryptionKey = "NWmuViz2xsT8dUkmaDfO1mabxLJnWI2wpMNqX2nSkbo="
+
 type ApiTestSuite struct {
 	suite.Suite
 	dbClient       *sql.DB
@@ -20,6 +38,9 @@ type ApiTestSuite struct {
 	adminToken     string
 	user1Token     string
 	user2Token     string
+	rsaKey         *rsa.PrivateKey
+	retiredKey     *rsa.PrivateKey
+	notifierFile   string
 }
 
 func (suite *ApiTestSuite) SetupTest() {
@@ -27,16 +48,26 @@ func (suite *ApiTestSuite) SetupTest() {
 	dsn, suite.dbClient, suite.terminateMysql = setupMysqlContainer()
 
 	os.Setenv("DB_DSN", dsn)
-	os.Setenv("JWT_SECRET_KEY", "supersecret")
+	os.Setenv("JWT_SECRET_KEY", "supersecret-key-for-the-api-tests")
+	os.Setenv("JWT_KEYS_FILE", suite.writeSigningKeys())
+	os.Setenv("JWT_HS256_ACCEPT_UNTIL", time.Now().Add(time.Hour).Format(time.RFC3339))
+	os.Setenv("MFA_ENCRYPTION_KEY", testMfaEncryptionKey)
 	os.Setenv("LOG_LEVEL", "DEBUG")
 
-	config.InitLog()
-	init := config.Init()
+	suite.notifierFile = filepath.Join(suite.T().TempDir(), "notifications.jsonl")
+	os.Setenv("NOTIFIER", "file")
+	os.Setenv("NOTIFIER_FILE", suite.notifierFile)
+
+	cfg, err := pkg.LoadConfig()
+	suite.Require().NoError(err)
+
+	config.InitLog(cfg.LogLevel, cfg.LogFormat)
+	init := config.Init(cfg)
 	suite.app = router.Init(init)
 
-	suite.adminToken, _ = generateToken(1, "admin@example.com", 1)
-	suite.user1Token, _ = generateToken(2, "user1@example.com", 2)
-	suite.user2Token, _ = generateToken(3, "user2@example.com", 2)
+	suite.adminToken, _ = suite.generateToken(1, "admin@example.com", 1)
+	suite.user1Token, _ = suite.generateToken(2, "user1@example.com", 2)
+	suite.user2Token, _ = suite.generateToken(3, "user2@example.com", 2)
 }
 
 func (suite *ApiTestSuite) TearDownTest() {
@@ -50,16 +81,129 @@ func (suite *ApiTestSuite) TearDownTest() {
 
 	os.Unsetenv("DB_DSN")
 	os.Unsetenv("JWT_SECRET_KEY")
+	os.Unsetenv("JWT_KEYS_FILE")
+	os.Unsetenv("JWT_HS256_ACCEPT_UNTIL")
+	os.Unsetenv("MFA_ENCRYPTION_KEY")
 	os.Unsetenv("LOG_LEVEL")
+	os.Unsetenv("NOTIFIER")
+	os.Unsetenv("NOTIFIER_FILE")
 }
 
-func generateToken(userId int, email string, roleId int) (string, error) {
+// generateToken starts a session for the user directly in the database and signs an access token bound to it.
+func (suite *ApiTestSuite) generateToken(userId int, email string, roleId int) (string, error) {
+	sessionId, _ := pkg.GenerateRandomToken(16)
+	refreshToken, _ := pkg.GenerateRandomToken(32)
+
+	_, err := suite.dbClient.Exec("INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at) VALUES (?, ?, ?, ?)",
+		userId, sessionId, pkg.HashToken(refreshToken), time.Now().Add(time.Hour*24))
+	if err != nil {
+		return "", err
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": userId,
 		"email":   email,
 		"role_id": roleId,
+		"sid":     sessionId,
 		"exp":     time.Now().Add(time.Hour * 2).Unix(),
 	})
 
 	return token.SignedString([]byte(os.Getenv("JWT_SECRET_KEY")))
 }
+
+// login signs the user in through the API and returns the issued tokens.
+func (suite *ApiTestSuite) login(email, password string) dao.TokenResponse {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users/login", strings.NewReader(fmt.Sprintf(`{"email": "%s", "password": "%s"}`, email, password)))
+	suite.app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var response struct {
+		Data dao.TokenResponse `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+
+	return response.Data
+}
+
+// writeSigningKeys writes a key rotation schedule to a temporary directory and returns the key set file path.
+// "rsa-previous" was active before "ed-current" took over signing, "ed-next" is scheduled for tomorrow
+// and "rsa-retired" is no longer accepted.
+func (suite *ApiTestSuite) writeSigningKeys() string {
+	dir := suite.T().TempDir()
+	now := time.Now().UTC()
+
+	suite.rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	suite.retiredKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	_, edCurrent, _ := ed25519.GenerateKey(rand.Reader)
+	_, edNext, _ := ed25519.GenerateKey(rand.Reader)
+
+	keys := []struct {
+		kid        string
+		key        interface{}
+		activeFrom time.Time
+		retireAt   time.Time
+	}{
+		{"rsa-retired", suite.retiredKey, now.Add(-72 * time.Hour), now.Add(-time.Hour)},
+		{"rsa-previous", suite.rsaKey, now.Add(-48 * time.Hour), now.Add(24 * time.Hour)},
+		{"ed-current", edCurrent, now.Add(-24 * time.Hour), time.Time{}},
+		{"ed-next", edNext, now.Add(24 * time.Hour), time.Time{}},
+	}
+
+	var entries []map[string]interface{}
+	for _, k := range keys {
+		der, err := x509.MarshalPKCS8PrivateKey(k.key)
+		suite.Require().NoError(err)
+
+		keyFile := k.kid + ".pem"
+		err = os.WriteFile(filepath.Join(dir, keyFile), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+		suite.Require().NoError(err)
+
+		entry := map[string]interface{}{"kid": k.kid, "private_key_file": keyFile, "active_from": k.activeFrom}
+		if !k.retireAt.IsZero() {
+			entry["retire_at"] = k.retireAt
+		}
+		entries = append(entries, entry)
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"keys": entries})
+	path := filepath.Join(dir, "jwt_keys.json")
+	suite.Require().NoError(os.WriteFile(path, data, 0600))
+
+	return path
+}
+
+// lastMessage returns the most recent notification sent to the given address, if any.
+func (suite *ApiTestSuite) lastMessage(to string) (pkg.Message, bool) {
+	data, err := os.ReadFile(suite.notifierFile)
+	if err != nil {
+		return pkg.Message{}, false
+	}
+
+	var last pkg.Message
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var message pkg.Message
+		if json.Unmarshal([]byte(line), &message) == nil && message.To == to {
+			last, found = message, true
+		}
+	}
+
+	return last, found
+}
+
+// signUp creates a user through the API and returns the token of the verification link sent to them.
+func (suite *ApiTestSuite) signUp(email, password string) string {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users", strings.NewReader(fmt.Sprintf(`{"email": "%s", "password": "%s"}`, email, password)))
+	suite.app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	message, sent := suite.lastMessage(email)
+	suite.Require().True(sent)
+
+	link, err := url.Parse(regexp.MustCompile(`https?://\S+`).FindString(message.Body))
+	suite.Require().NoError(err)
+
+	return link.Query().Get("token")
+}
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"bytes"
+	"event-booking-api/app/pkg"
+	"event-booking-api/app/router"
+	"event-booking-api/config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (suite *ApiTestSuite) TestTracing() {
+	const traceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		token           string
+		traceparent     string
+		expectedStatus  int
+		expectedSpans   []string
+		expectedTraceId string
+	}{
+		{"SuccessContinuesTraceparent", "POST", "/api/events/2/register", suite.user1Token, fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceId), http.StatusCreated,
+			[]string{"POST /api/events/:eventId/register", "RegisterService.RegisterUserForEvent", "gorm.query", "gorm.create"}, traceId},
+		{"SuccessStartsTrace", "GET", "/api/events/1", "", "", http.StatusOK,
+			[]string{"GET /api/events/:eventId", "EventService.GetEventById", "gorm.query"}, ""},
+		{"SuccessHealthProbeNotTraced", "GET", "/healthz", "", "", http.StatusOK, nil, ""},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			recorder := tracetest.NewSpanRecorder()
+
+			cfg, err := pkg.LoadConfig()
+			suite.Require().NoError(err)
+			init := config.Init(cfg)
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			app := router.Init(init)
+
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			spans := recorder.Ended()
+			if tt.expectedSpans == nil {
+				assert.Empty(suite.T(), spans)
+				return
+			}
+
+			names := make(map[string]trace.SpanContext)
+			for _, span := range spans {
+				names[span.Name()] = span.SpanContext()
+				assert.Equal(suite.T(), spans[0].SpanContext().TraceID(), span.SpanContext().TraceID(), span.Name())
+			}
+			for _, name := range tt.expectedSpans {
+				assert.Contains(suite.T(), names, name)
+			}
+
+			serviceSpan := names[tt.expectedSpans[1]]
+			if tt.expectedTraceId != "" {
+				assert.Equal(suite.T(), tt.expectedTraceId, serviceSpan.TraceID().String())
+			}
+			assert.True(suite.T(), strings.Contains(logs.String(), serviceSpan.TraceID().String()), "log lines include the trace id")
+		})
+	}
+}
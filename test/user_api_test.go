@@ -3,10 +3,13 @@ package test
 import (
 	"encoding/json"
 	"event-booking-api/app/domain/dao"
+	"event-booking-api/app/pkg"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
@@ -19,13 +22,15 @@ func (suite *ApiTestSuite) TestAddUser() {
 		password       string
 		roleId         int
 		expectedStatus int
+		expectedRoleId int
 	}{
-		{"SuccessAddAdmin", "admin2@example.com", "adminpass", 1, http.StatusCreated},
-		{"SuccessAddUser", "user3@example.com", "userpass", 2, http.StatusCreated},
-		{"FailureWrongEmailFormat", "wrongemail", "userpass", 2, http.StatusBadRequest},
-		{"FailureMissingEmail", "", "userpass", 2, http.StatusBadRequest},
-		{"FailureMissingPassword", "user3@example.com", "", 2, http.StatusBadRequest},
-		{"FailureEmailExist", "user1@example.com", "userpass", 2, http.StatusConflict},
+		{"SuccessSelfDeclaredAdminGetsUserRole", "admin2@example.com", "adminpass", 1, http.StatusCreated, 2},
+		{"SuccessSelfDeclaredOrganizerGetsUserRole", "organizer@example.com", "organizerpass", 3, http.StatusCreated, 2},
+		{"SuccessAddUser", "user3@example.com", "userpass", 2, http.StatusCreated, 2},
+		{"FailureWrongEmailFormat", "wrongemail", "userpass", 2, http.StatusBadRequest, 0},
+		{"FailureMissingEmail", "", "userpass", 2, http.StatusBadRequest, 0},
+		{"FailureMissingPassword", "user3@example.com", "", 2, http.StatusBadRequest, 0},
+		{"FailureEmailExist", "user1@example.com", "userpass", 2, http.StatusConflict, 0},
 	}
 
 	for _, tt := range tests {
@@ -42,16 +47,50 @@ func (suite *ApiTestSuite) TestAddUser() {
 				return
 			}
 
+			var response struct {
+				ResponseKey     string           `json:"response_key"`
+				ResponseMessage string           `json:"response_message"`
+				Data            dao.UserResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedRoleId, response.Data.RoleID)
+			assert.False(suite.T(), response.Data.EmailVerified)
+
+			_, sent := suite.lastMessage(tt.email)
+			assert.True(suite.T(), sent)
+
 			var actualPasswordHash string
-			err := suite.dbClient.QueryRow("SELECT password FROM users WHERE email = ? AND role_id = ?", tt.email, tt.roleId).Scan(&actualPasswordHash)
+			var actualRoleId int
+			err = suite.dbClient.QueryRow("SELECT password, role_id FROM users WHERE email = ?", tt.email).Scan(&actualPasswordHash, &actualRoleId)
 			assert.NoError(suite.T(), err)
 
+			assert.Equal(suite.T(), tt.expectedRoleId, actualRoleId)
+
 			err = bcrypt.CompareHashAndPassword([]byte(actualPasswordHash), []byte(tt.password))
 			assert.NoError(suite.T(), err)
 		})
 	}
 }
 
+func (suite *ApiTestSuite) TestSelfDeclaredAdminCannotListUsers() {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users", strings.NewReader(`{"email": "admin2@example.com", "password": "adminpass", "role_id": 1}`))
+	suite.app.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	session := suite.login("admin2@example.com", "adminpass")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/users", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", session.AccessToken))
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
 func (suite *ApiTestSuite) TestGetAllUser() {
 	tests := []struct {
 		name           string
@@ -153,7 +192,7 @@ func (suite *ApiTestSuite) TestUpdateUserById() {
 
 	for _, tt := range tests {
 		suite.Run(tt.name, func() {
-			payloads := fmt.Sprintf(`{"email": "%s", "password": "%s"}`, tt.email, tt.password)
+			payloads := fmt.Sprintf(`{"email": "%s", "password": "%s", "role_id": 1}`, tt.email, tt.password)
 
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/users/%v", tt.userId), strings.NewReader(payloads))
@@ -169,10 +208,12 @@ func (suite *ApiTestSuite) TestUpdateUserById() {
 			}
 
 			var actualEmail, actualPasswordHash string
-			err := suite.dbClient.QueryRow("SELECT email, password FROM users WHERE id = ?", tt.userId).Scan(&actualEmail, &actualPasswordHash)
+			var actualRoleId int
+			err := suite.dbClient.QueryRow("SELECT email, password, role_id FROM users WHERE id = ?", tt.userId).Scan(&actualEmail, &actualPasswordHash, &actualRoleId)
 			assert.NoError(suite.T(), err)
 
 			assert.Equal(suite.T(), tt.expectedEmail, actualEmail)
+			assert.Equal(suite.T(), 2, actualRoleId)
 
 			err = bcrypt.CompareHashAndPassword([]byte(actualPasswordHash), []byte(tt.expectedPassword))
 			assert.NoError(suite.T(), err)
@@ -216,6 +257,73 @@ func (suite *ApiTestSuite) TestDeleteUserById() {
 	}
 }
 
+func (suite *ApiTestSuite) TestDeleteUserByIdRemovesRegistrations() {
+	_, err := suite.dbClient.Exec("INSERT INTO registers (event_id, user_id, status) VALUES (?, ?, ?)", 1, 2, dao.RegisterStatusWaitlisted)
+	assert.NoError(suite.T(), err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/users/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user2Token))
+	suite.app.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	var count int
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM registers WHERE user_id = ? AND deleted_at IS NULL", 3).Scan(&count)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, count)
+
+	var actualStatus string
+	err = suite.dbClient.QueryRow("SELECT status FROM registers WHERE event_id = ? AND user_id = ?", 1, 2).Scan(&actualStatus)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), dao.RegisterStatusConfirmed, actualStatus)
+
+	err = suite.dbClient.QueryRow("SELECT COUNT(*) FROM refresh_tokens WHERE user_id = ? AND revoked_at IS NULL", 3).Scan(&count)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, count)
+}
+
+func (suite *ApiTestSuite) TestUpdateUserRoleById() {
+	tests := []struct {
+		name           string
+		userId         int
+		payloads       string
+		token          string
+		expectedStatus int
+		expectedRoleId int
+	}{
+		{"SuccessPromoteToOrganizer", 2, `{"role_id": 3}`, suite.adminToken, http.StatusOK, 3},
+		{"FailureMissingRoleId", 2, `{}`, suite.adminToken, http.StatusBadRequest, 0},
+		{"FailureRoleNotFound", 2, `{"role_id": 99}`, suite.adminToken, http.StatusNotFound, 0},
+		{"FailureUserNotFound", 99, `{"role_id": 3}`, suite.adminToken, http.StatusNotFound, 0},
+		{"FailureMissingToken", 3, `{"role_id": 1}`, "", http.StatusUnauthorized, 0},
+		{"FailureNotTheAdmin", 3, `{"role_id": 1}`, suite.user2Token, http.StatusUnauthorized, 0},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/users/%v/role", tt.userId), strings.NewReader(tt.payloads))
+			if tt.token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			}
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var actualRoleId int
+			err := suite.dbClient.QueryRow("SELECT role_id FROM users WHERE id = ?", tt.userId).Scan(&actualRoleId)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedRoleId, actualRoleId)
+		})
+	}
+}
+
 func (suite *ApiTestSuite) TestLoginUser() {
 	tests := []struct {
 		name           string
@@ -245,15 +353,381 @@ func (suite *ApiTestSuite) TestLoginUser() {
 			}
 
 			var response struct {
-				ResponseKey     string `json:"response_key"`
-				ResponseMessage string `json:"response_message"`
-				Data            string `json:"data"`
+				ResponseKey     string            `json:"response_key"`
+				ResponseMessage string            `json:"response_message"`
+				Data            dao.TokenResponse `json:"data"`
 			}
 
 			err := json.Unmarshal(w.Body.Bytes(), &response)
 			assert.NoError(suite.T(), err)
 
-			assert.NotEmpty(suite.T(), response.Data)
+			assert.NotEmpty(suite.T(), response.Data.AccessToken)
+			assert.NotEmpty(suite.T(), response.Data.RefreshToken)
+			assert.Equal(suite.T(), "Bearer", response.Data.TokenType)
+
+			var actualUserId int
+			err = suite.dbClient.QueryRow("SELECT user_id FROM refresh_tokens WHERE token_hash = ?", pkg.HashToken(response.Data.RefreshToken)).Scan(&actualUserId)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), 2, actualUserId)
+		})
+	}
+}
+
+func (suite *ApiTestSuite) TestRefreshToken() {
+	first := suite.login("user1@example.com", "userpass")
+
+	tests := []struct {
+		name           string
+		refreshToken   func() string
+		expectedStatus int
+	}{
+		{"SuccessRotateRefreshToken", func() string { return first.RefreshToken }, http.StatusOK},
+		{"FailureReusedRefreshToken", func() string { return first.RefreshToken }, http.StatusUnauthorized},
+		{"FailureInvalidRefreshToken", func() string { return "invalid" }, http.StatusUnauthorized},
+		{"FailureMissingRefreshToken", func() string { return "" }, http.StatusBadRequest},
+	}
+
+	var rotated dao.TokenResponse
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			payloads := fmt.Sprintf(`{"refresh_token": "%s"}`, tt.refreshToken())
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/token/refresh", strings.NewReader(payloads))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				ResponseKey     string            `json:"response_key"`
+				ResponseMessage string            `json:"response_message"`
+				Data            dao.TokenResponse `json:"data"`
+			}
+
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(suite.T(), err)
+
+			assert.NotEmpty(suite.T(), response.Data.AccessToken)
+			assert.NotEqual(suite.T(), first.RefreshToken, response.Data.RefreshToken)
+			rotated = response.Data
+		})
+	}
+
+	suite.Run("ReuseRevokesWholeFamily", func() {
+		payloads := fmt.Sprintf(`{"refresh_token": "%s"}`, rotated.RefreshToken)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/token/refresh", strings.NewReader(payloads))
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", "/api/users/2", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", rotated.AccessToken))
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+
+		var active int
+		err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM refresh_tokens WHERE user_id = ? AND revoked_at IS NULL AND token_hash IN (?, ?)",
+			2, pkg.HashToken(first.RefreshToken), pkg.HashToken(rotated.RefreshToken)).Scan(&active)
+		assert.NoError(suite.T(), err)
+
+		assert.Equal(suite.T(), 0, active)
+	})
+}
+
+func (suite *ApiTestSuite) TestLogoutUser() {
+	session := suite.login("user1@example.com", "userpass")
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"SuccessLogout", session.AccessToken, http.StatusOK},
+		{"FailureSessionRevoked", session.AccessToken, http.StatusUnauthorized},
+		{"FailureMissingToken", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/logout", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	suite.Run("FailureRefreshAfterLogout", func() {
+		payloads := fmt.Sprintf(`{"refresh_token": "%s"}`, session.RefreshToken)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/users/token/refresh", strings.NewReader(payloads))
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	})
+
+	suite.Run("OtherSessionsStayActive", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/users/2", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", suite.user1Token))
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusOK, w.Code)
+	})
+}
+
+func (suite *ApiTestSuite) TestRequestPasswordReset() {
+	tests := []struct {
+		name           string
+		email          string
+		expectedStatus int
+		expectedResets int
+	}{
+		{"SuccessRequestReset", "user1@example.com", http.StatusOK, 1},
+		{"SuccessUnknownEmail", "unknown@example.com", http.StatusOK, 0},
+		{"FailureWrongEmailFormat", "wrongemail", http.StatusBadRequest, 0},
+		{"FailureMissingEmail", "", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			payloads := fmt.Sprintf(`{"email": "%s"}`, tt.email)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/password/reset", strings.NewReader(payloads))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+
+			var actualResets int
+			err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM password_resets JOIN users ON password_resets.user_id = users.id WHERE users.email = ?", tt.email).Scan(&actualResets)
+			assert.NoError(suite.T(), err)
+
+			assert.Equal(suite.T(), tt.expectedResets, actualResets)
+
+			_, sent := suite.lastMessage(tt.email)
+			assert.Equal(suite.T(), tt.expectedResets > 0, sent)
 		})
 	}
 }
+
+func (suite *ApiTestSuite) TestResetPassword() {
+	session := suite.login("user1@example.com", "userpass")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/users/password/reset", strings.NewReader(`{"email": "user1@example.com"}`))
+	suite.app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	message, sent := suite.lastMessage("user1@example.com")
+	suite.Require().True(sent)
+	match := regexp.MustCompile(`reset your password: (\S+)`).FindStringSubmatch(message.Body)
+	suite.Require().Len(match, 2)
+	resetToken := match[1]
+
+	expiredToken, _ := pkg.GenerateRandomToken(32)
+	_, err := suite.dbClient.Exec("INSERT INTO password_resets (user_id, token_hash, expires_at) VALUES (?, ?, ?)",
+		2, pkg.HashToken(expiredToken), time.Now().Add(-time.Minute))
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name           string
+		token          string
+		password       string
+		expectedStatus int
+	}{
+		{"FailureMissingPassword", resetToken, "", http.StatusBadRequest},
+		{"FailureExpiredToken", expiredToken, "newpass", http.StatusUnauthorized},
+		{"FailureInvalidToken", "invalid", "newpass", http.StatusUnauthorized},
+		{"SuccessResetPassword", resetToken, "newpass", http.StatusOK},
+		{"FailureTokenAlreadyUsed", resetToken, "otherpass", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			payloads := fmt.Sprintf(`{"token": "%s", "password": "%s"}`, tt.token, tt.password)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/password/reset/confirm", strings.NewReader(payloads))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	suite.Run("NewPasswordIsSet", func() {
+		var actualPasswordHash string
+		err := suite.dbClient.QueryRow("SELECT password FROM users WHERE id = ?", 2).Scan(&actualPasswordHash)
+		assert.NoError(suite.T(), err)
+
+		err = bcrypt.CompareHashAndPassword([]byte(actualPasswordHash), []byte("newpass"))
+		assert.NoError(suite.T(), err)
+	})
+
+	suite.Run("ExistingSessionsRevoked", func() {
+		for _, token := range []string{session.AccessToken, suite.user1Token} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/users/2", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+		}
+	})
+}
+
+func (suite *ApiTestSuite) TestVerifyEmail() {
+	verificationToken := suite.signUp("user3@example.com", "userpass")
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"FailureMissingToken", "", http.StatusBadRequest},
+		{"FailureInvalidToken", "invalid", http.StatusUnauthorized},
+		{"SuccessVerifyEmail", verificationToken, http.StatusOK},
+		{"FailureTokenAlreadyUsed", verificationToken, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", fmt.Sprintf("/api/users/verify?token=%s", tt.token), nil)
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	suite.Run("EmailIsVerified", func() {
+		var verified bool
+		err := suite.dbClient.QueryRow("SELECT email_verified_at IS NOT NULL FROM users WHERE email = ?", "user3@example.com").Scan(&verified)
+		assert.NoError(suite.T(), err)
+
+		assert.True(suite.T(), verified)
+	})
+}
+
+func (suite *ApiTestSuite) TestVerifyEmailAfterEmailChange() {
+	verificationToken := suite.signUp("user3@example.com", "userpass")
+	session := suite.login("user3@example.com", "userpass")
+
+	var userId int
+	err := suite.dbClient.QueryRow("SELECT id FROM users WHERE email = ?", "user3@example.com").Scan(&userId)
+	suite.Require().NoError(err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/api/users/%v", userId), strings.NewReader(`{"email": "attacker@example.com"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", session.AccessToken))
+	suite.app.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	suite.Run("FailureTokenOfPreviousEmail", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/users/verify?token=%s", verificationToken), nil)
+		suite.app.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	})
+
+	suite.Run("EmailIsNotVerified", func() {
+		var verified bool
+		err := suite.dbClient.QueryRow("SELECT email_verified_at IS NOT NULL FROM users WHERE id = ?", userId).Scan(&verified)
+		assert.NoError(suite.T(), err)
+
+		assert.False(suite.T(), verified)
+	})
+}
+
+func (suite *ApiTestSuite) TestUnverifiedUserCannotBook() {
+	verificationToken := suite.signUp("user3@example.com", "userpass")
+	session := suite.login("user3@example.com", "userpass")
+
+	book := func() (int, int) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/events", strings.NewReader(`{"name": "Test Event 3", "description": "This is a test event 3", "location": "Tokyo", "event_time": "2030-08-26T12:00:00Z"}`))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", session.AccessToken))
+		suite.app.ServeHTTP(w, req)
+		addEventStatus := w.Code
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("POST", "/api/events/2/register", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", session.AccessToken))
+		suite.app.ServeHTTP(w, req)
+
+		return addEventStatus, w.Code
+	}
+
+	suite.Run("FailureUnverified", func() {
+		addEventStatus, registerStatus := book()
+
+		assert.Equal(suite.T(), http.StatusUnauthorized, addEventStatus)
+		assert.Equal(suite.T(), http.StatusUnauthorized, registerStatus)
+	})
+
+	suite.Run("SuccessVerified", func() {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/users/verify?token=%s", verificationToken), nil)
+		suite.app.ServeHTTP(w, req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		addEventStatus, registerStatus := book()
+
+		assert.Equal(suite.T(), http.StatusCreated, addEventStatus)
+		assert.Equal(suite.T(), http.StatusCreated, registerStatus)
+	})
+}
+
+func (suite *ApiTestSuite) TestResendVerification() {
+	suite.signUp("user3@example.com", "userpass")
+	session := suite.login("user3@example.com", "userpass")
+
+	tests := []struct {
+		name           string
+		token          string
+		setup          func()
+		expectedStatus int
+	}{
+		{"FailureSentTooRecently", session.AccessToken, func() {}, http.StatusTooManyRequests},
+		{"SuccessResend", session.AccessToken, func() {
+			_, err := suite.dbClient.Exec("UPDATE email_verifications SET created_at = ? WHERE user_id = (SELECT id FROM users WHERE email = ?)",
+				time.Now().Add(-2*time.Minute), "user3@example.com")
+			suite.Require().NoError(err)
+		}, http.StatusOK},
+		{"FailureAlreadyVerified", suite.user1Token, func() {}, http.StatusConflict},
+		{"FailureMissingToken", "", func() {}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			tt.setup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/users/verify/resend", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tt.token))
+			suite.app.ServeHTTP(w, req)
+
+			assert.Equal(suite.T(), tt.expectedStatus, w.Code)
+		})
+	}
+
+	suite.Run("NewLinkSent", func() {
+		var sent int
+		err := suite.dbClient.QueryRow("SELECT COUNT(*) FROM email_verifications JOIN users ON email_verifications.user_id = users.id WHERE users.email = ?", "user3@example.com").Scan(&sent)
+		assert.NoError(suite.T(), err)
+
+		assert.Equal(suite.T(), 2, sent)
+	})
+}